@@ -0,0 +1,28 @@
+package gppErrors
+
+import (
+	"fmt"
+)
+
+/*
+edge_not_found.go
+Description:
+
+	The error returned when a planner is told about an edge
+	that is not in the graph.
+*/
+
+// Types
+// =====
+
+type EdgeNotFound struct {
+	From int64
+	To   int64
+}
+
+// Methods
+// =======
+
+func (e EdgeNotFound) Error() string {
+	return fmt.Sprintf("edge from node %v to node %v not found in graph", e.From, e.To)
+}
@@ -0,0 +1,27 @@
+package gppErrors
+
+import (
+	"fmt"
+)
+
+/*
+goal_node_not_found.go
+Description:
+
+	The error returned when the goal node of a plan
+	is not in the graph.
+*/
+
+// Types
+// =====
+
+type GoalNodeNotFound struct {
+	ID int64
+}
+
+// Methods
+// =======
+
+func (e GoalNodeNotFound) Error() string {
+	return fmt.Sprintf("goal node %v not found in graph", e.ID)
+}
@@ -0,0 +1,29 @@
+package gppErrors
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+infeasible_constraints.go
+Description:
+
+	The error returned when paths to the goal exist, but none
+	of them stays within the resource limits of a constrained search.
+*/
+
+// Types
+// =====
+
+type InfeasibleConstraints struct {
+	Graph  graph.Graph
+	Limits []float64 // The upper bound on each cost component (+Inf if unconstrained)
+}
+
+// Methods
+// =======
+
+func (ic InfeasibleConstraints) Error() string {
+	return fmt.Sprintf("no path in graph %v satisfies the resource limits %v", ic.Graph, ic.Limits)
+}
@@ -0,0 +1,29 @@
+package gppErrors
+
+import (
+	"fmt"
+)
+
+/*
+invalid_option.go
+Description:
+
+	The error returned when an option or constraint given to a
+	planner is out of range (e.g., a negative limit or index).
+	Name is the name of the option, as written in the planner's API.
+*/
+
+// Types
+// =====
+
+type InvalidOption struct {
+	Name   string
+	Reason string
+}
+
+// Methods
+// =======
+
+func (e InvalidOption) Error() string {
+	return fmt.Sprintf("invalid option %v: %v", e.Name, e.Reason)
+}
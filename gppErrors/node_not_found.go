@@ -0,0 +1,33 @@
+package gppErrors
+
+import (
+	"fmt"
+)
+
+/*
+node_not_found.go
+Description:
+
+	The error returned when a node that a tool needs (other than
+	the start or goal of a plan, e.g., a landmark) is not in the graph.
+	Role says what the node was used for.
+*/
+
+// Types
+// =====
+
+type NodeNotFound struct {
+	ID   int64
+	Role string
+}
+
+// Methods
+// =======
+
+func (e NodeNotFound) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("node %v not found in graph", e.ID)
+	}
+
+	return fmt.Sprintf("%v node %v not found in graph", e.Role, e.ID)
+}
@@ -0,0 +1,32 @@
+package gppErrors
+
+import "fmt"
+
+/*
+search_budget_exceeded.go
+Description:
+
+	The error returned when a search is stopped because it used up
+	one of the budgets (expansions, heap size or cost) it was given.
+*/
+
+// Types
+// =====
+
+type SearchBudgetExceeded struct {
+	Budget     string  // The name of the budget that ran out
+	Limit      float64 // The value of the budget that ran out
+	Statistics SearchStatistics
+}
+
+// Methods
+// =======
+
+func (sbe SearchBudgetExceeded) Error() string {
+	return fmt.Sprintf(
+		"search exceeded its %v budget of %v after %v expansions",
+		sbe.Budget,
+		sbe.Limit,
+		sbe.Statistics.Expansions,
+	)
+}
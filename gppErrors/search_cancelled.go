@@ -0,0 +1,41 @@
+package gppErrors
+
+import "fmt"
+
+/*
+search_cancelled.go
+Description:
+
+	The error returned when a search is stopped because its
+	context was cancelled or its deadline passed.
+*/
+
+// Types
+// =====
+
+type SearchCancelled struct {
+	Cause      error
+	Statistics SearchStatistics
+}
+
+// Methods
+// =======
+
+func (sc SearchCancelled) Error() string {
+	return fmt.Sprintf(
+		"search cancelled after %v expansions: %v",
+		sc.Statistics.Expansions,
+		sc.Cause,
+	)
+}
+
+/*
+Unwrap
+Description:
+
+	Returns the context error that caused the cancellation, so that
+	errors.Is(err, context.DeadlineExceeded) works as expected.
+*/
+func (sc SearchCancelled) Unwrap() error {
+	return sc.Cause
+}
@@ -0,0 +1,25 @@
+package gppErrors
+
+/*
+search_statistics.go
+Description:
+
+	Defines the statistics that are attached to the errors
+	returned when a search is stopped before it finishes.
+*/
+
+// Types
+// =====
+
+/*
+SearchStatistics
+Description:
+
+	A snapshot of how much work a search had done when it was stopped.
+*/
+type SearchStatistics struct {
+	Expansions   int     // The number of graph nodes that were expanded
+	HeapSize     int     // The number of planning nodes waiting in the heap
+	MaxHeapSize  int     // The largest number of planning nodes that were ever in the heap
+	FrontierCost float64 // The cost of the last planning node popped off the heap
+}
@@ -0,0 +1,27 @@
+package gppErrors
+
+import (
+	"fmt"
+)
+
+/*
+start_node_not_found.go
+Description:
+
+	The error returned when the start node of a plan
+	is not in the graph.
+*/
+
+// Types
+// =====
+
+type StartNodeNotFound struct {
+	ID int64
+}
+
+// Methods
+// =======
+
+func (e StartNodeNotFound) Error() string {
+	return fmt.Sprintf("start node %v not found in graph", e.ID)
+}
@@ -0,0 +1,29 @@
+package gppErrors
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+unsupported_graph.go
+Description:
+
+	The error returned when a planner is given a graph
+	that it cannot plan over.
+*/
+
+// Types
+// =====
+
+type UnsupportedGraph struct {
+	Graph  graph.Graph
+	Reason string
+}
+
+// Methods
+// =======
+
+func (e UnsupportedGraph) Error() string {
+	return fmt.Sprintf("graph of type %T is not supported: %v", e.Graph, e.Reason)
+}
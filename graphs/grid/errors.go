@@ -0,0 +1,94 @@
+package grid_graph
+
+import "fmt"
+
+/*
+errors.go
+Description:
+
+	Defines the errors returned by the grid graph.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+InvalidConnectivityError
+Description:
+
+	Returned when a grid is given a connectivity that does not
+	match its number of dimensions (e.g., Connect26 for a 2D grid).
+*/
+type InvalidConnectivityError struct {
+	Connectivity Connectivity
+	Dimensions   int
+}
+
+func (e InvalidConnectivityError) Error() string {
+	return fmt.Sprintf("connectivity %v is not supported by %vD grids", int(e.Connectivity), e.Dimensions)
+}
+
+/*
+InvalidCostError
+Description:
+
+	Returned when a cell is given a traversal cost that is not positive and finite.
+*/
+type InvalidCostError struct {
+	Cost float64
+}
+
+func (e InvalidCostError) Error() string {
+	return fmt.Sprintf("cell cost %v must be positive and finite", e.Cost)
+}
+
+/*
+InvalidOriginError
+Description:
+
+	Returned when a grid is given an origin that does not have
+	one component per dimension of the grid.
+*/
+type InvalidOriginError struct {
+	Length     int
+	Dimensions int
+}
+
+func (e InvalidOriginError) Error() string {
+	return fmt.Sprintf("origin has %v components, but the grid is %vD", e.Length, e.Dimensions)
+}
+
+/*
+InvalidResolutionError
+Description:
+
+	Returned when a grid is given a cell size that is not positive and finite.
+*/
+type InvalidResolutionError struct {
+	Resolution float64
+}
+
+func (e InvalidResolutionError) Error() string {
+	return fmt.Sprintf("resolution %v must be positive and finite", e.Resolution)
+}
+
+/*
+OccupancyShapeError
+Description:
+
+	Returned when an occupancy array is ragged. Index locates the
+	row (or layer) whose length differs from the first one.
+*/
+type OccupancyShapeError struct {
+	Index    []int
+	Expected int
+	Received int
+}
+
+func (e OccupancyShapeError) Error() string {
+	return fmt.Sprintf(
+		"occupancy row %v has length %v, but the first row has length %v",
+		e.Index, e.Received, e.Expected,
+	)
+}
@@ -0,0 +1,950 @@
+package grid_graph
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+	"math"
+)
+
+/*
+grid.go
+Description:
+
+	Defines a graph over the cells of a 2D or 3D occupancy grid. Each free
+	cell is a node, and each free cell is connected to its free neighbors
+	(4 or 8 of them in 2D, 6 or 26 in 3D). Nodes and edges are computed
+	from the occupancy when they are requested, so large grids do not
+	store any edges.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+DiagonalPolicy
+Description:
+
+	Decides when a diagonal move between two free cells is allowed,
+	based on the cells that the move passes between (the corners).
+	The corners of a move are the cells reached by taking only some
+	of its steps along each axis (2 corners in 2D, up to 6 in 3D).
+*/
+type DiagonalPolicy int
+
+const (
+	NoCornerCutting    DiagonalPolicy = iota // All corners must be free
+	AllowCornerCutting                       // At least one corner must be free
+	AllowSqueezing                           // The corners may all be blocked
+)
+
+/*
+Connectivity
+Description:
+
+	The number of neighbors of each cell: Connect4 (orthogonal) or Connect8
+	(orthogonal and diagonal) for 2D grids, and Connect6 (orthogonal) or
+	Connect26 (orthogonal and diagonal) for 3D grids.
+*/
+type Connectivity int
+
+const (
+	Connect4  Connectivity = 4
+	Connect6  Connectivity = 6
+	Connect8  Connectivity = 8
+	Connect26 Connectivity = 26
+)
+
+/*
+Grid
+Description:
+
+	A graph whose nodes are the free cells of a 2D or 3D occupancy grid.
+	It implements graph.WeightedUndirected.
+
+	Every cell has a traversal cost (1 by default). The weight of the edge
+	between two neighboring cells is the length of the step between their
+	centers times the average of their traversal costs, so with the default
+	costs, orthogonal steps cost CellSize and diagonal steps cost
+	sqrt(2) * CellSize (or sqrt(3) * CellSize in 3D).
+
+	The ID of the cell {x, y} is y*width + x, and the ID of the cell
+	{x, y, z} is (z*height + y)*width + x. All cells start free.
+
+	Positions are related to cells by the origin (the position of the
+	corner of cell 0, the zero vector by default) and the cell size
+	(1 by default), which are changed with SetOrigin and SetResolution.
+*/
+type Grid struct {
+	Diagonal DiagonalPolicy
+
+	origin       *mat.VecDense // The position of the corner of cell 0 (nil for the zero vector)
+	resolution   float64       // The side length of a cell (0 for the default of 1)
+	shape        []int
+	connectivity Connectivity
+	steps        [][]int // The steps to each neighbor allowed by the connectivity
+	blocked      []bool
+	costs        []float64 // The traversal cost of each cell (nil if every cell costs 1)
+	nFree        int
+}
+
+var _ graph.WeightedUndirected = &Grid{}
+
+// =========
+// Functions
+// =========
+
+/*
+New
+Description:
+
+	Creates a new 8-connected 2D Grid with width x height free cells.
+*/
+func New(width, height int) *Grid {
+	return newWithShape([]int{width, height}, Connect8)
+}
+
+/*
+New3D
+Description:
+
+	Creates a new 26-connected 3D Grid with width x height x depth free cells.
+*/
+func New3D(width, height, depth int) *Grid {
+	return newWithShape([]int{width, height, depth}, Connect26)
+}
+
+/*
+FromOccupancy
+Description:
+
+	Creates an 8-connected 2D Grid from an occupancy array, where
+	occupancy[y][x] is true if the cell {x, y} is blocked.
+	Returns an OccupancyShapeError if the rows have different lengths.
+*/
+func FromOccupancy(occupancy [][]bool) (*Grid, error) {
+	// Input Processing
+	height := len(occupancy)
+	width := 0
+	if height > 0 {
+		width = len(occupancy[0])
+	}
+
+	for y, row := range occupancy {
+		if len(row) != width {
+			return nil, OccupancyShapeError{Index: []int{y}, Expected: width, Received: len(row)}
+		}
+	}
+
+	// Algorithm
+	g := New(width, height)
+	for y, row := range occupancy {
+		for x, isBlocked := range row {
+			if isBlocked {
+				g.Block(x, y)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+/*
+FromOccupancy3D
+Description:
+
+	Creates a 26-connected 3D Grid from an occupancy array, where
+	occupancy[z][y][x] is true if the cell {x, y, z} is blocked.
+	Returns an OccupancyShapeError if the array is ragged.
+*/
+func FromOccupancy3D(occupancy [][][]bool) (*Grid, error) {
+	// Input Processing
+	depth := len(occupancy)
+	height, width := 0, 0
+	if depth > 0 {
+		height = len(occupancy[0])
+		if height > 0 {
+			width = len(occupancy[0][0])
+		}
+	}
+
+	for z, layer := range occupancy {
+		if len(layer) != height {
+			return nil, OccupancyShapeError{Index: []int{z}, Expected: height, Received: len(layer)}
+		}
+		for y, row := range layer {
+			if len(row) != width {
+				return nil, OccupancyShapeError{Index: []int{z, y}, Expected: width, Received: len(row)}
+			}
+		}
+	}
+
+	// Algorithm
+	g := New3D(width, height, depth)
+	for z, layer := range occupancy {
+		for y, row := range layer {
+			for x, isBlocked := range row {
+				if isBlocked {
+					g.Block(x, y, z)
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+/*
+newWithShape
+Description:
+
+	Creates a new Grid of free cells with the given shape and connectivity.
+*/
+func newWithShape(shape []int, connectivity Connectivity) *Grid {
+	// Constants
+	nCells := 1
+	for _, size := range shape {
+		nCells *= max(size, 0)
+	}
+
+	// Algorithm
+	return &Grid{
+		shape:        shape,
+		connectivity: connectivity,
+		steps:        stepsFor(len(shape), connectivity),
+		blocked:      make([]bool, nCells),
+		nFree:        nCells,
+	}
+}
+
+/*
+stepsFor
+Description:
+
+	Returns the steps from a cell to its neighbors in a grid with the given
+	number of dimensions and connectivity. Orthogonal connectivities only
+	step along one axis; the others step along any combination of axes.
+*/
+func stepsFor(dimensions int, connectivity Connectivity) [][]int {
+	// Constants
+	orthogonalOnly := connectivity == Connect4 || connectivity == Connect6
+
+	// Enumerate every offset in {-1, 0, 1}^dimensions
+	var steps [][]int
+	nOffsets := int(math.Pow(3, float64(dimensions)))
+	for code := 0; code < nOffsets; code++ {
+		step := make([]int, dimensions)
+		nMoving := 0
+		for axis, rest := 0, code; axis < dimensions; axis, rest = axis+1, rest/3 {
+			step[axis] = rest%3 - 1
+			if step[axis] != 0 {
+				nMoving++
+			}
+		}
+
+		if nMoving == 0 || (orthogonalOnly && nMoving > 1) {
+			continue
+		}
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Dimensions
+Description:
+
+	Returns the number of axes of the grid (2 or 3).
+*/
+func (g *Grid) Dimensions() int {
+	return len(g.shape)
+}
+
+/*
+Shape
+Description:
+
+	Returns the number of cells along each axis.
+*/
+func (g *Grid) Shape() []int {
+	return append([]int(nil), g.shape...)
+}
+
+/*
+Width
+Description:
+
+	Returns the number of cells along the x axis.
+*/
+func (g *Grid) Width() int {
+	return g.shape[0]
+}
+
+/*
+Height
+Description:
+
+	Returns the number of cells along the y axis.
+*/
+func (g *Grid) Height() int {
+	return g.shape[1]
+}
+
+/*
+Depth
+Description:
+
+	Returns the number of cells along the z axis (1 for 2D grids).
+*/
+func (g *Grid) Depth() int {
+	if len(g.shape) < 3 {
+		return 1
+	}
+
+	return g.shape[2]
+}
+
+/*
+Connectivity
+Description:
+
+	Returns the connectivity of the grid.
+*/
+func (g *Grid) Connectivity() Connectivity {
+	return g.connectivity
+}
+
+/*
+SetConnectivity
+Description:
+
+	Changes the connectivity of the grid. Returns an InvalidConnectivityError
+	if the connectivity does not match the number of dimensions of the grid.
+*/
+func (g *Grid) SetConnectivity(connectivity Connectivity) error {
+	// Input Processing
+	valid := (len(g.shape) == 2 && (connectivity == Connect4 || connectivity == Connect8)) ||
+		(len(g.shape) == 3 && (connectivity == Connect6 || connectivity == Connect26))
+	if !valid {
+		return InvalidConnectivityError{Connectivity: connectivity, Dimensions: len(g.shape)}
+	}
+
+	// Algorithm
+	g.connectivity = connectivity
+	g.steps = stepsFor(len(g.shape), connectivity)
+
+	return nil
+}
+
+/*
+Contains
+Description:
+
+	Returns true if the cell is inside the grid.
+*/
+func (g *Grid) Contains(cell ...int) bool {
+	if len(cell) != len(g.shape) {
+		return false
+	}
+
+	for axis, index := range cell {
+		if index < 0 || index >= g.shape[axis] {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+ID
+Description:
+
+	Returns the ID of the node for the given cell, or -1 if
+	the cell is outside the grid.
+*/
+func (g *Grid) ID(cell ...int) int64 {
+	// Input Processing
+	if !g.Contains(cell...) {
+		return -1
+	}
+
+	// Algorithm
+	id := 0
+	for axis := len(cell) - 1; axis >= 0; axis-- {
+		id = id*g.shape[axis] + cell[axis]
+	}
+
+	return int64(id)
+}
+
+/*
+Cell
+Description:
+
+	Returns the cell of the node with the given ID,
+	or nil if the ID is outside the grid.
+*/
+func (g *Grid) Cell(id int64) []int {
+	// Input Processing
+	if id < 0 || id >= int64(len(g.blocked)) {
+		return nil
+	}
+
+	// Algorithm
+	cell := make([]int, len(g.shape))
+	rest := int(id)
+	for axis, size := range g.shape {
+		cell[axis] = rest % size
+		rest /= size
+	}
+
+	return cell
+}
+
+/*
+Block
+Description:
+
+	Marks the cell as blocked, which removes its node (and edges) from the graph.
+	Does nothing if the cell is outside the grid.
+*/
+func (g *Grid) Block(cell ...int) {
+	g.setBlocked(g.ID(cell...), true)
+}
+
+/*
+Unblock
+Description:
+
+	Marks the cell as free, which adds its node (and edges) to the graph.
+	Does nothing if the cell is outside the grid.
+*/
+func (g *Grid) Unblock(cell ...int) {
+	g.setBlocked(g.ID(cell...), false)
+}
+
+/*
+setBlocked
+Description:
+
+	Sets whether the cell with the given ID is blocked and
+	keeps the number of free cells up to date.
+*/
+func (g *Grid) setBlocked(id int64, blocked bool) {
+	// Input Processing
+	if id < 0 || g.blocked[id] == blocked {
+		return
+	}
+
+	// Algorithm
+	g.blocked[id] = blocked
+	if blocked {
+		g.nFree--
+	} else {
+		g.nFree++
+	}
+}
+
+/*
+IsBlocked
+Description:
+
+	Returns true if the cell is blocked or outside the grid.
+*/
+func (g *Grid) IsBlocked(cell ...int) bool {
+	id := g.ID(cell...)
+	return id < 0 || g.blocked[id]
+}
+
+/*
+SetCost
+Description:
+
+	Sets the traversal cost of the cell. Returns an InvalidCostError
+	if the cost is not positive and finite (use Block for cells that
+	cannot be traversed). Does nothing if the cell is outside the grid.
+*/
+func (g *Grid) SetCost(cost float64, cell ...int) error {
+	// Input Processing
+	if !(cost > 0.0) || math.IsInf(cost, 1) {
+		return InvalidCostError{Cost: cost}
+	}
+
+	id := g.ID(cell...)
+	if id < 0 {
+		return nil
+	}
+
+	// Algorithm
+	if g.costs == nil {
+		if cost == 1.0 {
+			return nil
+		}
+
+		g.costs = make([]float64, len(g.blocked))
+		for idx := range g.costs {
+			g.costs[idx] = 1.0
+		}
+	}
+	g.costs[id] = cost
+
+	return nil
+}
+
+/*
+Cost
+Description:
+
+	Returns the traversal cost of the cell (+Inf if the cell is outside the grid).
+*/
+func (g *Grid) Cost(cell ...int) float64 {
+	id := g.ID(cell...)
+	if id < 0 {
+		return math.Inf(1)
+	}
+
+	return g.costOf(id)
+}
+
+/*
+costOf
+Description:
+
+	Returns the traversal cost of the cell with the given ID.
+*/
+func (g *Grid) costOf(id int64) float64 {
+	if g.costs == nil {
+		return 1.0
+	}
+
+	return g.costs[id]
+}
+
+/*
+UniformCost
+Description:
+
+	Returns the traversal cost shared by every free cell and true,
+	or 0 and false if the free cells have different costs.
+*/
+func (g *Grid) UniformCost() (float64, bool) {
+	// Input Processing
+	if g.costs == nil {
+		return 1.0, true
+	}
+
+	// Algorithm
+	uniform := math.NaN()
+	for id, cost := range g.costs {
+		if g.blocked[id] {
+			continue
+		}
+
+		if math.IsNaN(uniform) {
+			uniform = cost
+		} else if cost != uniform {
+			return 0.0, false
+		}
+	}
+
+	if math.IsNaN(uniform) {
+		return 1.0, true
+	}
+
+	return uniform, true
+}
+
+/*
+CellSize
+Description:
+
+	Returns the side length of a cell (1 unless it was changed with SetResolution).
+*/
+func (g *Grid) CellSize() float64 {
+	if g.resolution == 0.0 {
+		return 1.0
+	}
+
+	return g.resolution
+}
+
+/*
+SetResolution
+Description:
+
+	Changes the side length of a cell. Returns an InvalidResolutionError
+	if the resolution is not positive and finite.
+*/
+func (g *Grid) SetResolution(resolution float64) error {
+	// Input Processing
+	if !(resolution > 0.0) || math.IsInf(resolution, 1) {
+		return InvalidResolutionError{Resolution: resolution}
+	}
+
+	// Algorithm
+	g.resolution = resolution
+
+	return nil
+}
+
+/*
+Origin
+Description:
+
+	Returns a copy of the position of the corner of cell 0
+	(the zero vector unless it was changed with SetOrigin).
+*/
+func (g *Grid) Origin() *mat.VecDense {
+	origin := mat.NewVecDense(len(g.shape), nil)
+	if g.origin != nil {
+		origin.CopyVec(g.origin)
+	}
+
+	return origin
+}
+
+/*
+SetOrigin
+Description:
+
+	Changes the position of the corner of cell 0 (a nil origin is the
+	zero vector). The origin is copied. Returns an InvalidOriginError if
+	the origin does not have one component per dimension of the grid.
+*/
+func (g *Grid) SetOrigin(origin *mat.VecDense) error {
+	// Input Processing
+	if origin == nil {
+		g.origin = nil
+		return nil
+	}
+
+	if origin.Len() != len(g.shape) {
+		return InvalidOriginError{Length: origin.Len(), Dimensions: len(g.shape)}
+	}
+
+	// Algorithm
+	g.origin = mat.VecDenseCopyOf(origin)
+
+	return nil
+}
+
+/*
+Position
+Description:
+
+	Returns the position of the center of the cell.
+	Returns nil if the cell does not have one index per axis.
+*/
+func (g *Grid) Position(cell ...int) *mat.VecDense {
+	// Input Processing
+	if len(cell) != len(g.shape) {
+		return nil
+	}
+
+	// Algorithm
+	position := mat.NewVecDense(len(cell), nil)
+	for axis, index := range cell {
+		value := (float64(index) + 0.5) * g.CellSize()
+		if g.origin != nil {
+			value += g.origin.AtVec(axis)
+		}
+		position.SetVec(axis, value)
+	}
+
+	return position
+}
+
+/*
+CellAt
+Description:
+
+	Returns the cell that contains the given position, and false
+	if the position is outside the grid.
+*/
+func (g *Grid) CellAt(position *mat.VecDense) ([]int, bool) {
+	// Input Processing
+	if position == nil || position.Len() != len(g.shape) {
+		return nil, false
+	}
+
+	// Algorithm
+	cell := make([]int, len(g.shape))
+	for axis := range cell {
+		value := position.AtVec(axis)
+		if g.origin != nil {
+			value -= g.origin.AtVec(axis)
+		}
+		cell[axis] = int(math.Floor(value / g.CellSize()))
+	}
+
+	return cell, g.Contains(cell...)
+}
+
+/*
+CanMove
+Description:
+
+	Returns true if the step from the free cell cell is allowed, where every
+	component of step is -1, 0 or 1 (and not all are 0). The target cell
+	must be free, and diagonal steps must respect the grid's DiagonalPolicy.
+	The connectivity is not checked (see From).
+*/
+func (g *Grid) CanMove(cell, step []int) bool {
+	// Input Processing
+	if len(cell) != len(g.shape) || len(step) != len(g.shape) {
+		return false
+	}
+
+	// Collect the axes that the step moves along
+	var movingAxes [3]int
+	nMoving := 0
+	for axis, delta := range step {
+		if delta != 0 {
+			movingAxes[nMoving] = axis
+			nMoving++
+		}
+	}
+
+	if nMoving == 0 || !g.isFreeOffset(cell, step, movingAxes[:nMoving], (1<<nMoving)-1) {
+		return false
+	}
+
+	if nMoving == 1 || g.Diagonal == AllowSqueezing {
+		return true
+	}
+
+	// Check the corners (every strict, non-empty subset of the moving axes)
+	nFreeCorners, nCorners := 0, (1<<nMoving)-2
+	for subset := 1; subset <= nCorners; subset++ {
+		if g.isFreeOffset(cell, step, movingAxes[:nMoving], subset) {
+			nFreeCorners++
+		}
+	}
+
+	if g.Diagonal == NoCornerCutting {
+		return nFreeCorners == nCorners
+	}
+
+	return nFreeCorners >= 1
+}
+
+/*
+isFreeOffset
+Description:
+
+	Returns true if the cell reached from cell by taking the step only
+	along the moving axes selected by the bit mask subset is free.
+*/
+func (g *Grid) isFreeOffset(cell, step, movingAxes []int, subset int) bool {
+	id := 0
+	for axis := len(cell) - 1; axis >= 0; axis-- {
+		index := cell[axis]
+		for bit, movingAxis := range movingAxes {
+			if movingAxis == axis && subset&(1<<bit) != 0 {
+				index += step[axis]
+			}
+		}
+
+		if index < 0 || index >= g.shape[axis] {
+			return false
+		}
+		id = id*g.shape[axis] + index
+	}
+
+	return !g.blocked[id]
+}
+
+/*
+Node
+Description:
+
+	Returns the node with the given ID, or nil if the ID is
+	outside the grid or its cell is blocked.
+*/
+func (g *Grid) Node(id int64) graph.Node {
+	// Input Processing
+	cell := g.Cell(id)
+	if cell == nil || g.blocked[id] {
+		return nil
+	}
+
+	// Algorithm
+	return &Node{id: id, Cell: cell}
+}
+
+/*
+Nodes
+Description:
+
+	Returns the nodes of all free cells in the graph.
+*/
+func (g *Grid) Nodes() graph.Nodes {
+	fc := &freeCells{grid: g}
+	fc.Reset()
+	return fc
+}
+
+/*
+From
+Description:
+
+	Returns the nodes that can be reached in one step from the node
+	with the given ID (an empty iterator if the node is not in the graph).
+*/
+func (g *Grid) From(id int64) graph.Nodes {
+	// Input Processing
+	cell := g.Cell(id)
+	if cell == nil || g.blocked[id] {
+		return graph.Empty
+	}
+
+	// Algorithm
+	var out []graph.Node
+	neighbor := make([]int, len(cell))
+	for _, step := range g.steps {
+		if !g.CanMove(cell, step) {
+			continue
+		}
+
+		for axis := range cell {
+			neighbor[axis] = cell[axis] + step[axis]
+		}
+		out = append(out, g.Node(g.ID(neighbor...)))
+	}
+
+	return iterator.NewOrderedNodes(out)
+}
+
+/*
+step
+Description:
+
+	Returns the step from the node with ID xid to the node with ID yid,
+	and whether the step is allowed by the grid's connectivity and DiagonalPolicy.
+*/
+func (g *Grid) step(xid, yid int64) ([]int, bool) {
+	// Input Processing
+	if g.Node(xid) == nil || g.Node(yid) == nil || xid == yid {
+		return nil, false
+	}
+
+	// Algorithm
+	from, to := g.Cell(xid), g.Cell(yid)
+	step := make([]int, len(from))
+	nMoving := 0
+	for axis := range from {
+		step[axis] = to[axis] - from[axis]
+		if step[axis] < -1 || step[axis] > 1 {
+			return nil, false
+		}
+		if step[axis] != 0 {
+			nMoving++
+		}
+	}
+
+	orthogonalOnly := g.connectivity == Connect4 || g.connectivity == Connect6
+	if orthogonalOnly && nMoving > 1 {
+		return nil, false
+	}
+
+	return step, g.CanMove(from, step)
+}
+
+/*
+HasEdgeBetween
+Description:
+
+	Returns true if a single step connects the nodes with the given IDs.
+*/
+func (g *Grid) HasEdgeBetween(xid, yid int64) bool {
+	_, ok := g.step(xid, yid)
+	return ok
+}
+
+/*
+Edge
+Description:
+
+	Returns the edge from the node with ID uid to the node
+	with ID vid, or nil if there is no such edge.
+*/
+func (g *Grid) Edge(uid, vid int64) graph.Edge {
+	return g.WeightedEdgeBetween(uid, vid)
+}
+
+/*
+EdgeBetween
+Description:
+
+	Returns the edge between the nodes with the given IDs, or nil if there is no such edge.
+*/
+func (g *Grid) EdgeBetween(xid, yid int64) graph.Edge {
+	return g.WeightedEdgeBetween(xid, yid)
+}
+
+/*
+WeightedEdge
+Description:
+
+	Returns the weighted edge from the node with ID uid to the node
+	with ID vid, or nil if there is no such edge.
+*/
+func (g *Grid) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	return g.WeightedEdgeBetween(uid, vid)
+}
+
+/*
+WeightedEdgeBetween
+Description:
+
+	Returns the weighted edge between the nodes with the given IDs,
+	or nil if there is no such edge.
+*/
+func (g *Grid) WeightedEdgeBetween(xid, yid int64) graph.WeightedEdge {
+	// Input Processing
+	w, ok := g.Weight(xid, yid)
+	if !ok || xid == yid {
+		return nil
+	}
+
+	// Algorithm
+	return simple.WeightedEdge{F: g.Node(xid), T: g.Node(yid), W: w}
+}
+
+/*
+Weight
+Description:
+
+	Returns the weight of the edge between the nodes with the given IDs
+	(the length of the step times the average traversal cost of the two cells).
+	Returns 0 and true if xid and yid are the same free cell, and
+	+Inf and false if there is no such edge.
+*/
+func (g *Grid) Weight(xid, yid int64) (float64, bool) {
+	// Input Processing
+	if xid == yid && g.Node(xid) != nil {
+		return 0.0, true
+	}
+
+	step, ok := g.step(xid, yid)
+	if !ok {
+		return math.Inf(1), false
+	}
+
+	// Algorithm
+	nMoving := 0
+	for _, delta := range step {
+		nMoving += delta * delta
+	}
+
+	length := math.Sqrt(float64(nMoving)) * g.CellSize()
+	return length * (g.costOf(xid) + g.costOf(yid)) / 2.0, true
+}
@@ -0,0 +1,129 @@
+package grid_graph
+
+import (
+	"math"
+)
+
+/*
+line_of_sight.go
+Description:
+
+	Defines the straight-line checks used by any-angle planners
+	(e.g., Theta*) on grids.
+*/
+
+// =======
+// Methods
+// =======
+
+/*
+LineOfSight
+Description:
+
+	Returns the cost of moving in a straight line from the center of the
+	cell with ID xid to the center of the cell with ID yid, and whether
+	every cell that the segment passes through is free.
+
+	The cost is the length of the segment inside each cell times the cost
+	of that cell, so for neighboring cells it matches Weight. Where the
+	segment passes exactly through a corner shared by several cells, the
+	crossing must be allowed by the grid's DiagonalPolicy (like a diagonal step).
+	The grid's connectivity is not used.
+
+Notes:
+
+  - The method has the signature of thetaStar.LineOfSight, so it can be
+    passed directly to the Theta* planners.
+*/
+func (g *Grid) LineOfSight(xid, yid int64) (float64, bool) {
+	// Input Processing
+	if g.Node(xid) == nil || g.Node(yid) == nil {
+		return math.Inf(1), false
+	}
+
+	from, to := g.Cell(xid), g.Cell(yid)
+
+	// Setup: the segment crosses |delta[axis]| cell boundaries along each axis
+	nAxes := len(from)
+	delta, direction := make([]int, nAxes), make([]int, nAxes)
+	squaredLength := 0
+	for axis := range from {
+		delta[axis] = to[axis] - from[axis]
+		direction[axis] = sign(delta[axis])
+		delta[axis] *= direction[axis]
+		squaredLength += delta[axis] * delta[axis]
+	}
+	length := math.Sqrt(float64(squaredLength)) * g.CellSize()
+
+	// Algorithm: walk from cell to cell, in the order that the boundaries are crossed
+	current := append([]int(nil), from...)
+	crossed := make([]int, nAxes) // The number of boundaries crossed along each axis
+	step := make([]int, nAxes)
+	t, cost := 0.0, 0.0
+	for {
+		// Find the axes whose next boundary is crossed first.
+		// The j-th boundary along an axis is crossed at t = (2j - 1) / (2 delta),
+		// and the comparisons are done with integers so that corners are found exactly.
+		next := -1
+		for axis := range delta {
+			step[axis] = 0
+			if crossed[axis] == delta[axis] {
+				continue
+			}
+
+			if next < 0 || (2*crossed[axis]+1)*delta[next] < (2*crossed[next]+1)*delta[axis] {
+				next = axis
+			}
+		}
+
+		if next < 0 {
+			break
+		}
+
+		for axis := range delta {
+			if crossed[axis] < delta[axis] && (2*crossed[axis]+1)*delta[next] == (2*crossed[next]+1)*delta[axis] {
+				step[axis] = direction[axis]
+			}
+		}
+
+		// Move into the next cell
+		if !g.CanMove(current, step) {
+			return math.Inf(1), false
+		}
+
+		nextT := float64(2*crossed[next]+1) / float64(2*delta[next])
+		cost += (nextT - t) * length * g.Cost(current...)
+		t = nextT
+
+		for axis := range step {
+			if step[axis] != 0 {
+				current[axis] += step[axis]
+				crossed[axis]++
+			}
+		}
+	}
+
+	cost += (1.0 - t) * length * g.Cost(current...)
+	return cost, true
+}
+
+// =========
+// Functions
+// =========
+
+/*
+sign
+Description:
+
+	Returns -1, 0 or 1 depending on the sign of v.
+*/
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
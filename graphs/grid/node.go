@@ -0,0 +1,38 @@
+package grid_graph
+
+/*
+node.go
+Description:
+
+	Defines a node for use in the grid graph.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+Node
+Description:
+
+	A free cell of a grid graph.
+	Cell holds the cell's index along each axis (e.g., {x, y}).
+*/
+type Node struct {
+	id   int64
+	Cell []int
+}
+
+// =======
+// Methods
+// =======
+
+/*
+ID
+Description:
+
+	Returns the ID of the node.
+*/
+func (n *Node) ID() int64 {
+	return n.id
+}
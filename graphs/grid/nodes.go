@@ -0,0 +1,87 @@
+package grid_graph
+
+import "gonum.org/v1/gonum/graph"
+
+/*
+nodes.go
+Description:
+
+	Defines an iterator over the free cells of a grid graph,
+	so that listing the nodes does not allocate a node for every cell up front.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+freeCells
+Description:
+
+	A graph.Nodes iterator over the free cells of a grid, in order of their IDs.
+*/
+type freeCells struct {
+	grid      *Grid
+	current   int64 // The ID of the current cell (-1 before the first call to Next)
+	remaining int   // The number of free cells after the current cell
+}
+
+var _ graph.Nodes = &freeCells{}
+
+// =======
+// Methods
+// =======
+
+/*
+Next
+Description:
+
+	Advances the iterator to the next free cell and returns
+	false once there are no more free cells.
+*/
+func (fc *freeCells) Next() bool {
+	if fc.remaining == 0 {
+		return false
+	}
+
+	for fc.current++; fc.grid.blocked[fc.current]; fc.current++ {
+	}
+	fc.remaining--
+
+	return true
+}
+
+/*
+Len
+Description:
+
+	Returns the number of free cells that have not been visited yet.
+*/
+func (fc *freeCells) Len() int {
+	return fc.remaining
+}
+
+/*
+Reset
+Description:
+
+	Returns the iterator to its initial state.
+*/
+func (fc *freeCells) Reset() {
+	fc.current = -1
+	fc.remaining = fc.grid.nFree
+}
+
+/*
+Node
+Description:
+
+	Returns the current node of the iterator (nil before the first call to Next).
+*/
+func (fc *freeCells) Node() graph.Node {
+	if fc.current < 0 {
+		return nil
+	}
+
+	return fc.grid.Node(fc.current)
+}
@@ -0,0 +1,458 @@
+package position_graph
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+directed_graph.go
+Description:
+
+	Defines a directed version of the position graph, for maps that
+	contain one-way connections (e.g., one-way streets and ramps).
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+DirectedPositionGraph
+Description:
+
+	A position graph whose edges can only be traversed from their From()
+	node to their To() node. It implements graph.WeightedDirected.
+
+	Nodes, edges, IDs and cost names are kept in an underlying
+	PositionGraph, so nodes and edges behave exactly as they do
+	in the undirected graph.
+*/
+type DirectedPositionGraph struct {
+	store        *PositionGraph
+	successors   map[int64]map[int64]int64 // node ID -> successor ID -> edge ID
+	predecessors map[int64]map[int64]int64 // node ID -> predecessor ID -> edge ID
+}
+
+var _ graph.WeightedDirected = &DirectedPositionGraph{}
+
+// =======
+// Methods
+// =======
+
+/*
+NewDirected
+Description:
+
+	Creates a new DirectedPositionGraph.
+*/
+func NewDirected() *DirectedPositionGraph {
+	// Constants
+
+	// Algorithm
+	return &DirectedPositionGraph{
+		store:        New(),
+		successors:   make(map[int64]map[int64]int64),
+		predecessors: make(map[int64]map[int64]int64),
+	}
+}
+
+/*
+Node
+Description:
+
+	Returns the node with the given ID, or nil if there is no such
+	node (as graph.Graph requires; use LookupNode to get an error instead).
+*/
+func (dpg *DirectedPositionGraph) Node(id int64) graph.Node {
+	return dpg.store.Node(id)
+}
+
+/*
+LookupNode
+Description:
+
+	Returns the node with the given ID, or a NodeNotFoundError
+	if there is no such node.
+*/
+func (dpg *DirectedPositionGraph) LookupNode(id int64) (*Node, error) {
+	return dpg.store.LookupNode(id)
+}
+
+/*
+Nodes
+Description:
+
+	Returns the nodes in the graph.
+*/
+func (dpg *DirectedPositionGraph) Nodes() graph.Nodes {
+	return dpg.store.Nodes()
+}
+
+/*
+From
+Description:
+
+	Returns the nodes that can be reached (in one step) from the node
+	with the given ID, i.e. its successors.
+	Panics with a NodeNotFoundError if there is no such node
+	(use Neighbors to get an error instead).
+*/
+func (dpg *DirectedPositionGraph) From(id int64) graph.Nodes {
+	// Input Processing
+	if _, ok := dpg.store.nodes[id]; !ok {
+		panic(NodeNotFoundError{id})
+	}
+
+	// Algorithm
+	return dpg.nodesIn(dpg.successors[id])
+}
+
+/*
+To
+Description:
+
+	Returns the nodes that can reach (in one step) the node with the
+	given ID, i.e. its predecessors.
+	Panics with a NodeNotFoundError if there is no such node.
+*/
+func (dpg *DirectedPositionGraph) To(id int64) graph.Nodes {
+	// Input Processing
+	if _, ok := dpg.store.nodes[id]; !ok {
+		panic(NodeNotFoundError{id})
+	}
+
+	// Algorithm
+	return dpg.nodesIn(dpg.predecessors[id])
+}
+
+/*
+Neighbors
+Description:
+
+	Returns the successors of the node with the given ID,
+	or a NodeNotFoundError if there is no such node.
+*/
+func (dpg *DirectedPositionGraph) Neighbors(id int64) (graph.Nodes, error) {
+	// Input Processing
+	if _, ok := dpg.store.nodes[id]; !ok {
+		return nil, NodeNotFoundError{id}
+	}
+
+	// Algorithm
+	return dpg.From(id), nil
+}
+
+/*
+nodesIn
+Description:
+
+	Returns the nodes whose IDs are the keys of the adjacency map adjacent.
+*/
+func (dpg *DirectedPositionGraph) nodesIn(adjacent map[int64]int64) graph.Nodes {
+	out := make([]graph.Node, 0, len(adjacent))
+	for neighborID := range adjacent {
+		out = append(out, dpg.store.nodes[neighborID])
+	}
+
+	return iterator.NewOrderedNodes(out)
+}
+
+/*
+HasEdgeBetween
+Description:
+
+	Returns whether or not there is an edge between the two nodes
+	with the given IDs, in either direction.
+*/
+func (dpg *DirectedPositionGraph) HasEdgeBetween(xid, yid int64) bool {
+	return dpg.HasEdgeFromTo(xid, yid) || dpg.HasEdgeFromTo(yid, xid)
+}
+
+/*
+HasEdgeFromTo
+Description:
+
+	Returns whether or not there is an edge from the node with
+	ID from to the node with ID to.
+*/
+func (dpg *DirectedPositionGraph) HasEdgeFromTo(from, to int64) bool {
+	_, ok := dpg.successors[from][to]
+	return ok
+}
+
+/*
+Edge
+Description:
+
+	Returns the edge from the node with ID from to the node with
+	ID to, or nil if there is no such edge.
+*/
+func (dpg *DirectedPositionGraph) Edge(from, to int64) graph.Edge {
+	// Constants
+
+	// Algorithm
+	edgeID, ok := dpg.successors[from][to]
+	if !ok {
+		return nil
+	}
+
+	return dpg.store.edges[edgeID]
+}
+
+/*
+WeightedEdge
+Description:
+
+	Returns the weighted edge from the node with ID from to the node
+	with ID to, or nil if there is no such edge.
+*/
+func (dpg *DirectedPositionGraph) WeightedEdge(from, to int64) graph.WeightedEdge {
+	edge := dpg.Edge(from, to)
+	if edge == nil {
+		return nil
+	}
+
+	return edge.(*PGEdge)
+}
+
+/*
+Weight
+Description:
+
+	Returns the weight of the edge from the node with ID xid to the
+	node with ID yid. Behaves like PositionGraph.Weight, so it returns
+	false if there is no such edge.
+*/
+func (dpg *DirectedPositionGraph) Weight(xid, yid int64) (float64, bool) {
+	// Collect edge
+	tempEdge := dpg.WeightedEdge(xid, yid)
+
+	// Return weight, if possible
+	if tempEdge == nil {
+		return 1e10, false
+	}
+
+	return tempEdge.Weight(), true
+}
+
+/*
+LookupEdge
+Description:
+
+	Finds the edge from the node with ID from to the node with ID to,
+	or returns an EdgeNotFoundError if there is no such edge.
+*/
+func (dpg *DirectedPositionGraph) LookupEdge(from, to int64) (*PGEdge, error) {
+	// Collect edge
+	edge := dpg.Edge(from, to)
+	if edge == nil {
+		return nil, EdgeNotFoundError{From: from, To: to}
+	}
+
+	return edge.(*PGEdge), nil
+}
+
+/*
+AddNode
+Description:
+
+	Adds a node to the graph.
+	If the graph already contains a node with the same ID, that node
+	is replaced (use AddNodeWithID to get an error instead).
+*/
+func (dpg *DirectedPositionGraph) AddNode(n Node) {
+	dpg.store.AddNode(n)
+}
+
+/*
+AddNodeWithID
+Description:
+
+	Adds a node with the given ID to the graph at a specific position.
+	Returns a NodeIDCollisionError if the graph already contains a node
+	with that ID.
+*/
+func (dpg *DirectedPositionGraph) AddNodeWithID(id int64, position *mat.VecDense) (Node, error) {
+	return dpg.store.AddNodeWithID(id, position)
+}
+
+/*
+AddNodeAt
+Description:
+
+	Adds a node to the graph at a specific position.
+	The node's ID is allocated monotonically, like in PositionGraph.AddNodeAt.
+*/
+func (dpg *DirectedPositionGraph) AddNodeAt(position *mat.VecDense) Node {
+	return dpg.store.AddNodeAt(position)
+}
+
+/*
+GetNodeAt
+Description:
+
+	Returns the node with the given position.
+*/
+func (dpg *DirectedPositionGraph) GetNodeAt(position *mat.VecDense) *Node {
+	return dpg.store.GetNodeAt(position)
+}
+
+/*
+AddEdgeBetween
+Description:
+
+	Adds an edge from the node from to the node to.
+	If there is already an edge from from to to, it is replaced.
+	An edge in the opposite direction is not affected.
+*/
+func (dpg *DirectedPositionGraph) AddEdgeBetween(from Node, to Node) PGEdge {
+	return dpg.AddEdgeWithCosts(from, to, nil)
+}
+
+/*
+AddEdgeWithCosts
+Description:
+
+	Adds an edge from the node from to the node to that carries
+	the given named costs (in addition to its DistanceCost).
+*/
+func (dpg *DirectedPositionGraph) AddEdgeWithCosts(from Node, to Node, costs map[string]float64) PGEdge {
+	// Constants
+
+	// Create edge
+	e := PGEdge{
+		graph: dpg.store,
+		from:  from.ID(),
+		to:    to.ID(),
+		costs: make(map[string]float64, len(costs)),
+	}
+	for name, value := range costs {
+		dpg.store.registerCostName(name)
+		e.costs[name] = value
+	}
+
+	// Reuse the ID of the edge that is being replaced (if any)
+	edgeID, replacing := dpg.successors[e.from][e.to]
+	if !replacing {
+		edgeID = dpg.store.nextEdgeID
+		dpg.store.nextEdgeID++
+	}
+	dpg.store.edges[edgeID] = &e
+
+	// Update adjacency
+	if _, ok := dpg.successors[e.from]; !ok {
+		dpg.successors[e.from] = make(map[int64]int64)
+	}
+	dpg.successors[e.from][e.to] = edgeID
+
+	if _, ok := dpg.predecessors[e.to]; !ok {
+		dpg.predecessors[e.to] = make(map[int64]int64)
+	}
+	dpg.predecessors[e.to][e.from] = edgeID
+
+	// Return edge
+	return e
+}
+
+/*
+SetEdgeCost
+Description:
+
+	Sets the named cost of the edge from the node with ID from to the
+	node with ID to. Setting the DistanceCost overrides the Euclidean distance.
+	Returns an EdgeNotFoundError if there is no such edge.
+*/
+func (dpg *DirectedPositionGraph) SetEdgeCost(from, to int64, name string, value float64) error {
+	// Input Processing
+	edgeID, ok := dpg.successors[from][to]
+	if !ok {
+		return EdgeNotFoundError{From: from, To: to}
+	}
+
+	// Algorithm
+	e := dpg.store.edges[edgeID]
+	if e.costs == nil {
+		e.costs = make(map[string]float64)
+	}
+	e.costs[name] = value
+	dpg.store.registerCostName(name)
+
+	return nil
+}
+
+/*
+CostNames
+Description:
+
+	Returns the names of all of the costs carried by edges in the graph.
+	DistanceCost is always first.
+*/
+func (dpg *DirectedPositionGraph) CostNames() []string {
+	return dpg.store.CostNames()
+}
+
+/*
+CostVector
+Description:
+
+	Returns the costs of the edge from the node with ID xid to the node
+	with ID yid (in the order given by CostNames()) and true, or nil and
+	false if there is no such edge.
+*/
+func (dpg *DirectedPositionGraph) CostVector(xid, yid int64) ([]float64, bool) {
+	// Input Processing
+	edge := dpg.WeightedEdge(xid, yid)
+	if edge == nil {
+		return nil, false
+	}
+
+	// Algorithm
+	return edge.(*PGEdge).CostVector(), true
+}
+
+/*
+RemoveNode
+Description:
+
+	Removes the node with the given ID from the graph
+	AND all associated edges (in both directions).
+*/
+func (dpg *DirectedPositionGraph) RemoveNode(id int64) {
+	// Remove node
+	delete(dpg.store.nodes, id)
+
+	// Remove edges
+	for successorID, edgeID := range dpg.successors[id] {
+		delete(dpg.store.edges, edgeID)
+		delete(dpg.predecessors[successorID], id)
+	}
+	for predecessorID, edgeID := range dpg.predecessors[id] {
+		delete(dpg.store.edges, edgeID)
+		delete(dpg.successors[predecessorID], id)
+	}
+	delete(dpg.successors, id)
+	delete(dpg.predecessors, id)
+}
+
+/*
+RemoveEdge
+Description:
+
+	Removes the edge from the node with ID from to the node with ID to.
+	An edge in the opposite direction is not affected.
+*/
+func (dpg *DirectedPositionGraph) RemoveEdge(from, to int64) {
+	// Constants
+
+	// Algorithm
+	edgeID, ok := dpg.successors[from][to]
+	if !ok {
+		return
+	}
+
+	delete(dpg.store.edges, edgeID)
+	delete(dpg.successors[from], to)
+	delete(dpg.predecessors[to], from)
+}
@@ -18,11 +18,17 @@ Description:
 
 /*
 PGEdge
+Description:
+
+	An edge between two nodes of the position graph.
+	Besides the Euclidean distance between its endpoints, an edge can
+	carry any number of named costs (e.g., "time", "energy" or "toll").
 */
 type PGEdge struct {
 	graph *PositionGraph
 	from  int64
 	to    int64
+	costs map[string]float64
 }
 
 // =======
@@ -69,6 +75,7 @@ func (e *PGEdge) ReversedEdge() graph.Edge {
 		graph: e.graph,
 		from:  e.to,
 		to:    e.from,
+		costs: e.costs,
 	}
 }
 
@@ -76,10 +83,25 @@ func (e *PGEdge) ReversedEdge() graph.Edge {
 Weight
 Description:
 
-	Returns the weight of the edge.
+	Returns the weight of the edge, which is its DistanceCost.
 */
 func (e *PGEdge) Weight() float64 {
 	// Constants
+
+	// Algorithm
+	distance, _ := e.Cost(DistanceCost)
+	return distance
+}
+
+/*
+EuclideanDistance
+Description:
+
+	Returns the Euclidean distance between the positions of the two
+	endpoints of the edge.
+*/
+func (e *PGEdge) EuclideanDistance() float64 {
+	// Constants
 	from := e.From().(*Node)
 	to := e.To().(*Node)
 
@@ -88,3 +110,68 @@ func (e *PGEdge) Weight() float64 {
 	distance.SubVec(to.Position, from.Position)
 	return mat.Norm(distance, 2)
 }
+
+/*
+Cost
+Description:
+
+	Returns the named cost of the edge and true, or zero and false if
+	the edge does not carry a cost with that name.
+	Every edge carries the DistanceCost, which defaults to the
+	Euclidean distance between its endpoints.
+*/
+func (e *PGEdge) Cost(name string) (float64, bool) {
+	// Constants
+
+	// Algorithm
+	if value, ok := e.costs[name]; ok {
+		return value, true
+	}
+
+	if name == DistanceCost {
+		return e.EuclideanDistance(), true
+	}
+
+	return 0.0, false
+}
+
+/*
+CostVector
+Description:
+
+	Returns the costs of the edge in the order given by the graph's
+	CostNames(). Costs that the edge does not carry are zero.
+*/
+func (e *PGEdge) CostVector() []float64 {
+	// Constants
+	names := e.graph.CostNames()
+
+	// Algorithm
+	out := make([]float64, len(names))
+	for idx, name := range names {
+		out[idx], _ = e.Cost(name)
+	}
+
+	return out
+}
+
+/*
+WeightedCost
+Description:
+
+	Returns the weighted sum of the named costs of the edge
+	(i.e., sum over name of weights[name] * Cost(name)).
+	Costs that the edge does not carry count as zero.
+*/
+func (e *PGEdge) WeightedCost(weights map[string]float64) float64 {
+	// Constants
+
+	// Algorithm
+	total := 0.0
+	for name, weight := range weights {
+		value, _ := e.Cost(name)
+		total += weight * value
+	}
+
+	return total
+}
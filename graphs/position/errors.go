@@ -23,3 +23,27 @@ func (e NodeNotFoundError) Error() string {
 		e.ID,
 	)
 }
+
+type NodeIDCollisionError struct {
+	ID int64
+}
+
+func (e NodeIDCollisionError) Error() string {
+	return fmt.Sprintf(
+		"Node with ID %v already exists",
+		e.ID,
+	)
+}
+
+type EdgeNotFoundError struct {
+	From int64
+	To   int64
+}
+
+func (e EdgeNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"Edge between nodes %v and %v not found",
+		e.From,
+		e.To,
+	)
+}
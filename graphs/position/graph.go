@@ -4,6 +4,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/iterator"
 	"gonum.org/v1/gonum/mat"
+	"slices"
 )
 
 /*
@@ -13,6 +14,19 @@ Description:
 	Defines a graph for use in the position graph.
 */
 
+// =========
+// Constants
+// =========
+
+/*
+DistanceCost
+Description:
+
+	The name of the cost that every edge carries. By default, it is the
+	Euclidean distance between the positions of the edge's endpoints.
+*/
+const DistanceCost = "distance"
+
 // =======
 // Objects
 // =======
@@ -21,8 +35,13 @@ Description:
 PositionGraph
 */
 type PositionGraph struct {
-	nodes map[int64]*Node
-	edges map[int64]*PGEdge
+	nodes     map[int64]*Node
+	edges     map[int64]*PGEdge
+	adjacency map[int64]map[int64]int64 // node ID -> neighbor ID -> edge ID
+	costNames []string
+
+	nextNodeID int64 // The ID given to the next node added with AddNodeAt
+	nextEdgeID int64 // The ID given to the next edge added with AddEdge
 }
 
 // =======
@@ -40,8 +59,10 @@ func New() *PositionGraph {
 
 	// Algorithm
 	return &PositionGraph{
-		nodes: make(map[int64]*Node),
-		edges: make(map[int64]*PGEdge),
+		nodes:     make(map[int64]*Node),
+		edges:     make(map[int64]*PGEdge),
+		adjacency: make(map[int64]map[int64]int64),
+		costNames: []string{DistanceCost},
 	}
 }
 
@@ -49,7 +70,8 @@ func New() *PositionGraph {
 Node
 Description:
 
-	Returns the node with the given ID.
+	Returns the node with the given ID, or nil if there is no such
+	node (as graph.Graph requires; use LookupNode to get an error instead).
 */
 func (pg *PositionGraph) Node(id int64) graph.Node {
 	// Constants
@@ -57,12 +79,31 @@ func (pg *PositionGraph) Node(id int64) graph.Node {
 	// Algorithm
 	out, ok := pg.nodes[id]
 	if !ok {
-		panic(NodeNotFoundError{id})
+		return nil
 	}
 
 	return out
 }
 
+/*
+LookupNode
+Description:
+
+	Returns the node with the given ID, or a NodeNotFoundError
+	if there is no such node.
+*/
+func (pg *PositionGraph) LookupNode(id int64) (*Node, error) {
+	// Constants
+
+	// Algorithm
+	out, ok := pg.nodes[id]
+	if !ok {
+		return nil, NodeNotFoundError{id}
+	}
+
+	return out, nil
+}
+
 /*
 Nodes
 Description:
@@ -87,6 +128,8 @@ Description:
 
 	Returns the nodes that can be reached from the node
 	with the given ID.
+	Panics with a NodeNotFoundError if there is no such node
+	(use Neighbors to get an error instead).
 */
 func (pg *PositionGraph) From(id int64) graph.Nodes {
 	// Input Processing
@@ -97,22 +140,32 @@ func (pg *PositionGraph) From(id int64) graph.Nodes {
 	// Constants
 
 	// Algorithm
-	var out []graph.Node
-	for _, e := range pg.edges {
-		if e.From().ID() == id {
-			out = append(out, e.To())
-		}
-
-		// e is not a self-loop
-		isASelfLoop := e.From().ID() == e.To().ID()
-		if (e.To().ID() == id) && !isASelfLoop {
-			out = append(out, e.From())
-		}
+	neighbors := pg.adjacency[id]
+	out := make([]graph.Node, 0, len(neighbors))
+	for neighborID := range neighbors {
+		out = append(out, pg.nodes[neighborID])
 	}
 
 	return iterator.NewOrderedNodes(out)
 }
 
+/*
+Neighbors
+Description:
+
+	Returns the nodes that can be reached from the node with the
+	given ID, or a NodeNotFoundError if there is no such node.
+*/
+func (pg *PositionGraph) Neighbors(id int64) (graph.Nodes, error) {
+	// Input Processing
+	if _, ok := pg.nodes[id]; !ok {
+		return nil, NodeNotFoundError{id}
+	}
+
+	// Algorithm
+	return pg.From(id), nil
+}
+
 /*
 HasEdgeBetween
 Description:
@@ -124,17 +177,8 @@ func (pg *PositionGraph) HasEdgeBetween(from, to int64) bool {
 	// Constants
 
 	// Algorithm
-	for _, e := range pg.edges {
-		if e.From().ID() == from && e.To().ID() == to {
-			return true
-		}
-
-		if e.From().ID() == to && e.To().ID() == from {
-			return true
-		}
-	}
-
-	return false
+	_, ok := pg.adjacency[from][to]
+	return ok
 }
 
 /*
@@ -142,18 +186,26 @@ Edge
 Description:
 
 	Returns the edge between the two nodes with the given IDs.
+	Because the graph is undirected, the edge is returned no matter which
+	direction it was added in; it is oriented so that its From() is the
+	node with ID from.
 */
 func (pg *PositionGraph) Edge(from, to int64) graph.Edge {
 	// Constants
 
 	// Algorithm
-	for _, e := range pg.edges {
-		if e.From().ID() == from && e.To().ID() == to {
-			return e
-		}
+	edgeID, ok := pg.adjacency[from][to]
+	if !ok {
+		return nil
 	}
 
-	return nil
+	// Reverse the edge if it was added in the to -> from direction
+	e := pg.edges[edgeID]
+	if e.from != from {
+		return e.ReversedEdge()
+	}
+
+	return e
 }
 
 /*
@@ -230,7 +282,29 @@ Description:
 */
 func (pg *PositionGraph) WeightedEdgeBetween(from, to int64) graph.WeightedEdge {
 	// Use Edge Between and cast it to the correct type
-	return pg.EdgeBetween(from, to).(*PGEdge)
+	edge := pg.EdgeBetween(from, to)
+	if edge == nil {
+		return nil
+	}
+
+	return edge.(*PGEdge)
+}
+
+/*
+LookupEdgeBetween
+Description:
+
+	Finds the edge between the two ids (in either direction), or
+	returns an EdgeNotFoundError if there is no such edge.
+*/
+func (pg *PositionGraph) LookupEdgeBetween(from, to int64) (*PGEdge, error) {
+	// Collect edge
+	edge := pg.EdgeBetween(from, to)
+	if edge == nil {
+		return nil, EdgeNotFoundError{From: from, To: to}
+	}
+
+	return edge.(*PGEdge), nil
 }
 
 /*
@@ -238,12 +312,44 @@ AddNode
 Description:
 
 	Adds a node to the graph.
+	If the graph already contains a node with the same ID, that node
+	is replaced (use AddNodeWithID to get an error instead).
 */
 func (pg *PositionGraph) AddNode(n Node) {
 	// Constants
 
 	// Algorithm
 	pg.nodes[n.ID()] = &n
+
+	// Make sure that automatically allocated IDs never collide with this node
+	pg.nextNodeID = max(pg.nextNodeID, n.ID()+1)
+}
+
+/*
+AddNodeWithID
+Description:
+
+	Adds a node with the given ID to the graph at a specific position.
+	Returns a NodeIDCollisionError if the graph already contains a node
+	with that ID.
+*/
+func (pg *PositionGraph) AddNodeWithID(id int64, position *mat.VecDense) (Node, error) {
+	// Input Processing
+	if _, ok := pg.nodes[id]; ok {
+		return Node{}, NodeIDCollisionError{ID: id}
+	}
+
+	// Create node
+	n := Node{
+		id:       id,
+		Position: position,
+	}
+
+	// Add node
+	pg.AddNode(n)
+
+	// Return node
+	return n, nil
 }
 
 /*
@@ -251,12 +357,15 @@ AddNodeAt
 Description:
 
 	Adds a node to the graph at a specific position.
+	The node's ID is allocated monotonically (like gonum's NewNode), so
+	IDs of removed nodes are never reused and existing nodes are never
+	overwritten.
 */
 func (pg *PositionGraph) AddNodeAt(position *mat.VecDense) Node {
 	// Constants
 
 	// Create node
-	var nextIndex int64 = int64(len(pg.nodes))
+	nextIndex := pg.nextNodeID
 	n := Node{
 		id:       nextIndex,
 		Position: position,
@@ -274,13 +383,41 @@ AddEdge
 Description:
 
 	Adds an edge to the graph.
+	If there is already an edge between the two nodes, in either
+	direction (the graph is undirected), it is silently replaced: the
+	new edge takes the old edge's ID, and the old edge's costs are lost.
+	Otherwise, the edge's ID is allocated monotonically, so IDs of
+	removed edges are never reused.
 */
 func (pg *PositionGraph) AddEdge(e PGEdge) {
 	// Constants
 
 	// Algorithm
-	var nextIndex int64 = int64(len(pg.edges))
+	// Reuse the ID of the edge that is being replaced (if any)
+	nextIndex, replacing := pg.adjacency[e.from][e.to]
+	if !replacing {
+		nextIndex = pg.nextEdgeID
+		pg.nextEdgeID++
+	}
 	pg.edges[nextIndex] = &e
+
+	// Update adjacency (in both directions, since the graph is undirected)
+	pg.setAdjacent(e.from, e.to, nextIndex)
+	pg.setAdjacent(e.to, e.from, nextIndex)
+}
+
+/*
+setAdjacent
+Description:
+
+	Records that the edge with ID edgeID connects the node with ID from
+	to the node with ID to.
+*/
+func (pg *PositionGraph) setAdjacent(from, to, edgeID int64) {
+	if _, ok := pg.adjacency[from]; !ok {
+		pg.adjacency[from] = make(map[int64]int64)
+	}
+	pg.adjacency[from][to] = edgeID
 }
 
 /*
@@ -306,6 +443,105 @@ func (pg *PositionGraph) AddEdgeBetween(from Node, to Node) PGEdge {
 	return e
 }
 
+/*
+AddEdgeWithCosts
+Description:
+
+	Adds an edge between two nodes in the graph that carries
+	the given named costs (in addition to its DistanceCost).
+*/
+func (pg *PositionGraph) AddEdgeWithCosts(from Node, to Node, costs map[string]float64) PGEdge {
+	// Constants
+
+	// Create edge
+	e := PGEdge{
+		graph: pg,
+		from:  from.ID(),
+		to:    to.ID(),
+		costs: make(map[string]float64, len(costs)),
+	}
+	for name, value := range costs {
+		pg.registerCostName(name)
+		e.costs[name] = value
+	}
+
+	// Add edge
+	pg.AddEdge(e)
+
+	// Return edge
+	return e
+}
+
+/*
+SetEdgeCost
+Description:
+
+	Sets the named cost of the edge between the two nodes with the
+	given IDs. Setting the DistanceCost overrides the Euclidean distance.
+	Returns an EdgeNotFoundError if there is no edge between the nodes.
+*/
+func (pg *PositionGraph) SetEdgeCost(from, to int64, name string, value float64) error {
+	// Input Processing
+	edgeID, ok := pg.adjacency[from][to]
+	if !ok {
+		return EdgeNotFoundError{From: from, To: to}
+	}
+
+	// Algorithm
+	e := pg.edges[edgeID]
+	if e.costs == nil {
+		e.costs = make(map[string]float64)
+	}
+	e.costs[name] = value
+	pg.registerCostName(name)
+
+	return nil
+}
+
+/*
+CostNames
+Description:
+
+	Returns the names of all of the costs carried by edges in the graph.
+	DistanceCost is always first; the other names follow in the order
+	in which they were first added to the graph.
+*/
+func (pg *PositionGraph) CostNames() []string {
+	return slices.Clone(pg.costNames)
+}
+
+/*
+CostVector
+Description:
+
+	Returns the costs of the edge between the two nodes with the given IDs
+	(in the order given by CostNames()) and true, or nil and false if
+	there is no such edge. This makes the graph a planning.VectorWeighted
+	graph, so it can be used with multi-objective planners.
+*/
+func (pg *PositionGraph) CostVector(xid, yid int64) ([]float64, bool) {
+	// Input Processing
+	edge := pg.EdgeBetween(xid, yid)
+	if edge == nil {
+		return nil, false
+	}
+
+	// Algorithm
+	return edge.(*PGEdge).CostVector(), true
+}
+
+/*
+registerCostName
+Description:
+
+	Adds name to the list of cost names in the graph, if it is not already there.
+*/
+func (pg *PositionGraph) registerCostName(name string) {
+	if !slices.Contains(pg.costNames, name) {
+		pg.costNames = append(pg.costNames, name)
+	}
+}
+
 /*
 GetNodeAt
 Description:
@@ -337,26 +573,29 @@ func (pg *PositionGraph) RemoveNode(id int64) {
 	delete(pg.nodes, id)
 
 	// Remove edges
-	for i, e := range pg.edges {
-		if e.From().ID() == id || e.To().ID() == id {
-			delete(pg.edges, i)
-		}
+	for neighborID, edgeID := range pg.adjacency[id] {
+		delete(pg.edges, edgeID)
+		delete(pg.adjacency[neighborID], id)
 	}
+	delete(pg.adjacency, id)
 }
 
 /*
 RemoveEdge
 Description:
 
-	Removes the edge between the two nodes with the given IDs.
+	Removes the edge between the two nodes with the given IDs
+	(no matter which direction it was added in).
 */
 func (pg *PositionGraph) RemoveEdge(from, to int64) {
 	// Constants
 
 	// Algorithm
-	for i, e := range pg.edges {
-		if e.From().ID() == from && e.To().ID() == to {
-			delete(pg.edges, i)
-		}
+	if !pg.HasEdgeBetween(from, to) {
+		return
 	}
+
+	delete(pg.edges, pg.adjacency[from][to])
+	delete(pg.adjacency[from], to)
+	delete(pg.adjacency[to], from)
 }
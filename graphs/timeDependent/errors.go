@@ -0,0 +1,65 @@
+package time_dependent_graph
+
+import "fmt"
+
+/*
+errors.go
+Description:
+
+	Defines the errors returned when building travel time profiles.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+InvalidBreakpointError
+Description:
+
+	Returned when a breakpoint of a profile cannot be used.
+	Index is the position of the breakpoint in the list given to the constructor.
+*/
+type InvalidBreakpointError struct {
+	Index  int
+	Reason string
+}
+
+func (e InvalidBreakpointError) Error() string {
+	return fmt.Sprintf("breakpoint %v is invalid: %v", e.Index, e.Reason)
+}
+
+/*
+InvalidPeriodError
+Description:
+
+	Returned when the period of a periodic profile is not a finite,
+	positive number.
+*/
+type InvalidPeriodError struct {
+	Period float64
+}
+
+func (e InvalidPeriodError) Error() string {
+	return fmt.Sprintf("the period %v is not a finite, positive number", e.Period)
+}
+
+/*
+FIFOViolationError
+Description:
+
+	Returned when a profile would let a vehicle that leaves at time From.Time
+	arrive after a vehicle that leaves later, at time To.Time.
+	(Between two breakpoints, the travel time may not fall faster than time passes.)
+*/
+type FIFOViolationError struct {
+	From Breakpoint
+	To   Breakpoint
+}
+
+func (e FIFOViolationError) Error() string {
+	return fmt.Sprintf(
+		"leaving at %v arrives at %v, but leaving earlier at %v arrives later at %v",
+		e.To.Time, e.To.Time+e.To.TravelTime, e.From.Time, e.From.Time+e.From.TravelTime,
+	)
+}
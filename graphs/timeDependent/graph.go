@@ -0,0 +1,133 @@
+package time_dependent_graph
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+	"math"
+)
+
+/*
+graph.go
+Description:
+
+	Defines a graph whose edges have time-dependent travel times,
+	built on top of an existing graph (e.g., a PositionGraph).
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+Graph
+Description:
+
+	Adds travel time profiles to the edges of an existing graph, which
+	provides the nodes and edges. Each profile applies to one direction
+	of an edge. Edges without a profile take the graph's weight as their
+	(constant) travel time if the graph is a graph.Weighted, and cannot be
+	traversed otherwise.
+*/
+type Graph struct {
+	graph.Graph
+	profiles map[[2]int64]*Profile
+}
+
+var _ planning.TimeDependent = &Graph{}
+
+/*
+New
+Description:
+
+	Creates a time-dependent graph with the nodes and edges of g
+	and no profiles.
+*/
+func New(g graph.Graph) *Graph {
+	return &Graph{
+		Graph:    g,
+		profiles: make(map[[2]int64]*Profile),
+	}
+}
+
+// =======
+// Methods
+// =======
+
+/*
+SetProfile
+Description:
+
+	Sets the travel time profile for moving from the node with ID from
+	to the node with ID to. A nil profile removes the profile.
+	Returns a gppErrors.EdgeNotFound error if the graph cannot move from
+	from to to.
+*/
+func (g *Graph) SetProfile(from, to int64, profile *Profile) error {
+	// Input Processing
+	if !g.hasEdgeFromTo(from, to) {
+		return gppErrors.EdgeNotFound{From: from, To: to}
+	}
+
+	// Algorithm
+	if profile == nil {
+		delete(g.profiles, [2]int64{from, to})
+		return nil
+	}
+
+	g.profiles[[2]int64{from, to}] = profile
+	return nil
+}
+
+/*
+Profile
+Description:
+
+	Returns the travel time profile for moving from the node with ID from
+	to the node with ID to, and false if that direction has no profile.
+*/
+func (g *Graph) Profile(from, to int64) (*Profile, bool) {
+	profile, ok := g.profiles[[2]int64{from, to}]
+	return profile, ok
+}
+
+/*
+TravelTime
+Description:
+
+	Returns the time needed to move from the node with ID uid to the node
+	with ID vid when leaving at time departure, and false if there is no
+	such edge (or it has neither a profile nor a weight).
+*/
+func (g *Graph) TravelTime(uid, vid int64, departure float64) (float64, bool) {
+	// Input Processing
+	if !g.hasEdgeFromTo(uid, vid) {
+		return math.Inf(1), false
+	}
+
+	// Algorithm
+	if profile, ok := g.profiles[[2]int64{uid, vid}]; ok {
+		return profile.TravelTime(departure), true
+	}
+
+	if weighted, ok := g.Graph.(graph.Weighted); ok {
+		return weighted.Weight(uid, vid)
+	}
+
+	return math.Inf(1), false
+}
+
+/*
+hasEdgeFromTo
+Description:
+
+	Returns true if the graph can move from the node with ID from
+	to the node with ID to (following edge directions on directed graphs).
+*/
+func (g *Graph) hasEdgeFromTo(from, to int64) bool {
+	if directed, ok := g.Graph.(graph.Directed); ok {
+		return directed.HasEdgeFromTo(from, to)
+	}
+
+	return from != to && g.HasEdgeBetween(from, to)
+}
@@ -0,0 +1,262 @@
+package time_dependent_graph
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+/*
+profile.go
+Description:
+
+	Defines piecewise-linear travel time profiles: the time needed to
+	traverse an edge as a function of the time of departure.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Breakpoint
+Description:
+
+	The travel time of an edge when leaving at time Time.
+*/
+type Breakpoint struct {
+	Time       float64
+	TravelTime float64
+}
+
+/*
+Profile
+Description:
+
+	A piecewise-linear travel time profile. Between two breakpoints,
+	the travel time is interpolated linearly. Before the first and after
+	the last breakpoint, it stays constant, unless the profile is periodic
+	(e.g., repeats every day), in which case the last breakpoint is
+	interpolated with the first breakpoint of the next period.
+
+	Profiles are created with NewProfile, NewPeriodicProfile or
+	ConstantProfile, which check that the profile satisfies the FIFO
+	property, and must not be modified afterwards. The zero value has no
+	breakpoints and is not usable (its methods panic).
+*/
+type Profile struct {
+	breakpoints []Breakpoint
+	period      float64 // The length of a period (0 if the profile does not repeat)
+}
+
+// =========
+// Functions
+// =========
+
+/*
+ConstantProfile
+Description:
+
+	Creates a profile whose travel time never changes.
+	Returns an InvalidBreakpointError if travelTime is negative or NaN
+	(like NewProfile).
+*/
+func ConstantProfile(travelTime float64) (*Profile, error) {
+	return NewProfile(Breakpoint{Time: 0.0, TravelTime: travelTime})
+}
+
+/*
+NewProfile
+Description:
+
+	Creates a profile from breakpoints sorted by time.
+	Returns an InvalidBreakpointError if there are no breakpoints, if a
+	time is not finite, if the times do not strictly increase or if a
+	travel time is negative or NaN, and a FIFOViolationError if the travel time falls faster than time passes
+	between two breakpoints.
+*/
+func NewProfile(breakpoints ...Breakpoint) (*Profile, error) {
+	// Input Processing
+	if err := checkBreakpoints(breakpoints); err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	return &Profile{breakpoints: slices.Clone(breakpoints)}, nil
+}
+
+/*
+NewPeriodicProfile
+Description:
+
+	Creates a profile that repeats every period (e.g., 24 hours) from
+	breakpoints sorted by time, whose times must be in [0, period).
+	Returns an InvalidPeriodError if period is not a finite, positive
+	number, and otherwise the same errors as NewProfile. Also checks the
+	FIFO property between the last breakpoint and the first breakpoint
+	of the next period.
+*/
+func NewPeriodicProfile(period float64, breakpoints ...Breakpoint) (*Profile, error) {
+	// Input Processing
+	if !(period > 0.0) || math.IsInf(period, 1) {
+		return nil, InvalidPeriodError{Period: period}
+	}
+
+	if err := checkBreakpoints(breakpoints); err != nil {
+		return nil, err
+	}
+
+	for idx, b := range breakpoints {
+		if b.Time < 0.0 || b.Time >= period {
+			return nil, InvalidBreakpointError{Index: idx, Reason: "the time is outside of the period"}
+		}
+	}
+
+	last := breakpoints[len(breakpoints)-1]
+	next := Breakpoint{Time: breakpoints[0].Time + period, TravelTime: breakpoints[0].TravelTime}
+	if next.Time+next.TravelTime < last.Time+last.TravelTime {
+		return nil, FIFOViolationError{From: last, To: next}
+	}
+
+	// Algorithm
+	return &Profile{breakpoints: slices.Clone(breakpoints), period: period}, nil
+}
+
+/*
+checkBreakpoints
+Description:
+
+	Checks the breakpoints of a profile (see NewProfile).
+*/
+func checkBreakpoints(breakpoints []Breakpoint) error {
+	if len(breakpoints) == 0 {
+		return InvalidBreakpointError{Index: 0, Reason: "a profile needs at least one breakpoint"}
+	}
+
+	for idx, b := range breakpoints {
+		if math.IsNaN(b.Time) || math.IsInf(b.Time, 0) {
+			return InvalidBreakpointError{Index: idx, Reason: "the time is not finite"}
+		}
+
+		if b.TravelTime < 0.0 || math.IsNaN(b.TravelTime) {
+			return InvalidBreakpointError{Index: idx, Reason: "the travel time is negative or NaN"}
+		}
+
+		if idx == 0 {
+			continue
+		}
+
+		previous := breakpoints[idx-1]
+		if b.Time <= previous.Time {
+			return InvalidBreakpointError{Index: idx, Reason: "the time is not after the previous breakpoint"}
+		}
+
+		if b.Time+b.TravelTime < previous.Time+previous.TravelTime {
+			return FIFOViolationError{From: previous, To: b}
+		}
+	}
+
+	return nil
+}
+
+// =======
+// Methods
+// =======
+
+/*
+TravelTime
+Description:
+
+	Returns the travel time when leaving at time departure.
+*/
+func (p *Profile) TravelTime(departure float64) float64 {
+	// Setup
+	breakpoints := p.breakpoints
+	if len(breakpoints) == 1 {
+		return breakpoints[0].TravelTime
+	}
+
+	first, last := breakpoints[0], breakpoints[len(breakpoints)-1]
+
+	// Periodic profiles: move the departure into [first.Time, first.Time + period)
+	if p.period > 0.0 {
+		t := first.Time + math.Mod(departure-first.Time, p.period)
+		if t < first.Time {
+			t += p.period
+		}
+
+		if t >= last.Time {
+			next := Breakpoint{Time: first.Time + p.period, TravelTime: first.TravelTime}
+			return interpolate(last, next, t)
+		}
+		departure = t
+	}
+
+	// Algorithm
+	if departure <= first.Time {
+		return first.TravelTime
+	}
+	if departure >= last.Time {
+		return last.TravelTime
+	}
+
+	idx := sort.Search(len(breakpoints), func(i int) bool { return breakpoints[i].Time > departure })
+	return interpolate(breakpoints[idx-1], breakpoints[idx], departure)
+}
+
+/*
+Arrival
+Description:
+
+	Returns the time of arrival when leaving at time departure.
+*/
+func (p *Profile) Arrival(departure float64) float64 {
+	return departure + p.TravelTime(departure)
+}
+
+/*
+MinTravelTime
+Description:
+
+	Returns the smallest travel time of the profile at any time of departure
+	(which is useful to build admissible heuristics).
+*/
+func (p *Profile) MinTravelTime() float64 {
+	smallest := math.Inf(1)
+	for _, b := range p.breakpoints {
+		smallest = min(smallest, b.TravelTime)
+	}
+
+	return smallest
+}
+
+/*
+Breakpoints
+Description:
+
+	Returns a copy of the breakpoints of the profile.
+*/
+func (p *Profile) Breakpoints() []Breakpoint {
+	return slices.Clone(p.breakpoints)
+}
+
+/*
+Period
+Description:
+
+	Returns the length of a period of the profile (0 if it does not repeat).
+*/
+func (p *Profile) Period() float64 {
+	return p.period
+}
+
+/*
+interpolate
+Description:
+
+	Returns the travel time at time t on the segment from breakpoint a to breakpoint b.
+*/
+func interpolate(a, b Breakpoint, t float64) float64 {
+	fraction := (t - a.Time) / (b.Time - a.Time)
+	return a.TravelTime + fraction*(b.TravelTime-a.TravelTime)
+}
@@ -0,0 +1,330 @@
+package aStar
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+ara.go
+Description:
+
+	Defines how plans are generated with Anytime Repairing A* (ARA*).
+	ARA* quickly finds a plan using a heuristic that is inflated by a
+	weight epsilon, and then lowers epsilon step by step, reusing the
+	previous search to improve the plan, until epsilon reaches 1 (and
+	the plan is optimal) or the context is done.
+*/
+
+// =========
+// Constants
+// =========
+
+const (
+	DefaultInitialEpsilon = 3.0
+	DefaultEpsilonStep    = 0.5
+)
+
+// ================
+// Type Definitions
+// ================
+
+/*
+AnytimeOptions
+Description:
+
+	Configures the ARA* search.
+	InitialEpsilon is the heuristic weight of the first search (DefaultInitialEpsilon if 0),
+	and EpsilonStep is how much it is lowered after each search (DefaultEpsilonStep if 0).
+	The search is limited by Budget (the zero value means no limits).
+*/
+type AnytimeOptions struct {
+	InitialEpsilon float64
+	EpsilonStep    float64
+	Budget         planning.Budget
+}
+
+/*
+AnytimePlan
+Description:
+
+	A plan found by ARA*.
+	The cost of the plan is at most SuboptimalityBound times the
+	cost of the optimal plan (a bound of 1 means that the plan is optimal).
+*/
+type AnytimePlan struct {
+	Plan               *planning.Plan
+	Epsilon            float64 // The heuristic weight of the search that found the plan
+	SuboptimalityBound float64
+}
+
+/*
+araNode
+Description:
+
+	Wraps a PlanningNode so that the open heap orders it by
+	CostToGo + epsilon * HeuristicCost.
+*/
+type araNode struct {
+	*PlanningNode
+	epsilon float64
+}
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlanAnytime
+Description:
+
+	Generates plans from node start to node end through the graph g
+	using the ARA* algorithm. Each time a better plan is found, it is
+	passed to improved (which may be nil) together with its suboptimality bound.
+	The search stops when epsilon reaches 1, when the context ctx is done or when
+	the budget is exceeded. The best plan found by then is returned.
+
+Notes:
+
+  - The suboptimality bounds only hold if the heuristic is consistent.
+    A nil heuristic is the same as a heuristic of zero.
+  - If the search is stopped before any plan is found, the error is a
+    gppErrors.SearchCancelled or a gppErrors.SearchBudgetExceeded.
+  - If start or end is not in the graph, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindPlanAnytime(
+	ctx context.Context,
+	g graph.Weighted,
+	start, end int64,
+	heuristic func(*PlanningNode) float64,
+	options AnytimeOptions,
+	improved func(AnytimePlan),
+) (*AnytimePlan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	if heuristic == nil {
+		heuristic = func(*PlanningNode) float64 { return 0.0 }
+	}
+
+	epsilon := options.InitialEpsilon
+	if epsilon == 0.0 {
+		epsilon = DefaultInitialEpsilon
+	}
+	epsilon = max(epsilon, 1.0)
+
+	step := options.EpsilonStep
+	if step <= 0.0 {
+		step = DefaultEpsilonStep
+	}
+
+	// Create the search
+	pn0 := &PlanningNode{
+		Graph:            g,
+		CurrentGraphNode: g.Node(start),
+		PreviousInPlan:   nil,
+		CostToGo:         0.0,
+	}
+	pn0.HeuristicCost = heuristic(pn0)
+
+	s := &araSearch{
+		end:       end,
+		heuristic: heuristic,
+		budget:    options.Budget,
+		epsilon:   epsilon,
+		best:      map[int64]*PlanningNode{start: pn0},
+		open:      planningHeap.NewIndexedPlanningHeap(),
+		closed:    make(map[int64]bool),
+		incons:    make(map[int64]bool),
+	}
+	s.open.Push(&araNode{pn0, epsilon})
+
+	// Algorithm
+	var result *AnytimePlan
+	for {
+		// Improve the plan with the current epsilon
+		if err := s.improvePath(ctx); err != nil {
+			if result != nil {
+				return result, nil
+			}
+			return nil, err
+		}
+
+		goal, ok := s.best[end]
+		if !ok {
+			return nil, gppErrors.NoPathFound{Graph: g}
+		}
+
+		// Report the plan if it is better than the previous one
+		bound := s.suboptimalityBound()
+		if result == nil || goal.CostToGo < result.Plan.CostToGo || bound < result.SuboptimalityBound {
+			result = &AnytimePlan{
+				Plan:               UnrollPlanFrom(goal),
+				Epsilon:            s.epsilon,
+				SuboptimalityBound: bound,
+			}
+			if improved != nil {
+				improved(*result)
+			}
+		}
+
+		// Stop once the plan is optimal
+		if s.epsilon <= 1.0 || bound <= 1.0 {
+			return result, nil
+		}
+
+		// Lower epsilon and prepare the next search
+		s.epsilon = max(1.0, s.epsilon-step)
+		s.reopen()
+	}
+}
+
+// =======
+// Objects
+// =======
+
+/*
+araSearch
+Description:
+
+	The state that ARA* keeps between its searches.
+*/
+type araSearch struct {
+	end       int64
+	heuristic func(*PlanningNode) float64
+	budget    planning.Budget
+	epsilon   float64
+	stats     gppErrors.SearchStatistics
+
+	best   map[int64]*PlanningNode // The cheapest planning node found for each graph node
+	open   *planningHeap.IndexedPlanningHeap
+	closed map[int64]bool
+	incons map[int64]bool // Closed nodes whose cost dropped during the current search
+}
+
+/*
+improvePath
+Description:
+
+	Expands nodes until no node in the open heap has a
+	lower (inflated) cost than the end.
+*/
+func (s *araSearch) improvePath(ctx context.Context) error {
+	for s.open.Len() > 0 {
+		// Stop once the end is at least as cheap as the rest of the frontier
+		top := s.open.Min().(*araNode)
+		if goal, ok := s.best[s.end]; ok && s.inflatedCost(goal) <= top.Cost() {
+			return nil
+		}
+
+		// Stop if we have run out of time or budget
+		s.stats.HeapSize = s.open.Len()
+		s.stats.FrontierCost = top.Cost()
+		if err := s.budget.Check(ctx, s.stats); err != nil {
+			return err
+		}
+
+		// Close and expand the node
+		s.open.PopMin()
+		s.closed[top.NodeID()] = true
+		s.stats.Expansions++
+
+		for _, newPN := range top.Expand(s.heuristic) {
+			newID := newPN.NodeID()
+			if current, ok := s.best[newID]; ok && current.CostToGo <= newPN.CostToGo {
+				continue
+			}
+
+			s.best[newID] = newPN
+			if s.closed[newID] {
+				s.incons[newID] = true
+				continue
+			}
+
+			s.open.Push(&araNode{newPN, s.epsilon})
+		}
+		s.stats.MaxHeapSize = max(s.stats.MaxHeapSize, s.open.Len())
+	}
+
+	return nil
+}
+
+/*
+suboptimalityBound
+Description:
+
+	Returns how much more expensive than the optimal plan the
+	current plan to the end can be.
+*/
+func (s *araSearch) suboptimalityBound() float64 {
+	// The cheapest (uninflated) cost of any plan through the open or inconsistent nodes
+	lowerBound := s.best[s.end].CostToGo
+	for id, pn := range s.best {
+		if s.open.Contains(id) || s.incons[id] {
+			lowerBound = min(lowerBound, pn.Cost())
+		}
+	}
+
+	// Algorithm
+	cost := s.best[s.end].CostToGo
+	if cost <= 0.0 {
+		return 1.0
+	}
+
+	if lowerBound <= 0.0 {
+		return s.epsilon
+	}
+
+	return min(s.epsilon, cost/lowerBound)
+}
+
+/*
+reopen
+Description:
+
+	Moves the inconsistent nodes into the open heap, re-sorts the heap for
+	the current epsilon and forgets which nodes were closed.
+*/
+func (s *araSearch) reopen() {
+	// Collect the nodes of the next search
+	var nodes []*PlanningNode
+	for s.open.Len() > 0 {
+		nodes = append(nodes, s.open.PopMin().(*araNode).PlanningNode)
+	}
+	for id := range s.incons {
+		nodes = append(nodes, s.best[id])
+	}
+
+	// Algorithm
+	for _, pn := range nodes {
+		s.open.Push(&araNode{pn, s.epsilon})
+	}
+	s.incons = make(map[int64]bool)
+	s.closed = make(map[int64]bool)
+}
+
+/*
+inflatedCost
+Description:
+
+	Returns the cost of the planning node pn with its heuristic inflated by epsilon.
+*/
+func (s *araSearch) inflatedCost(pn *PlanningNode) float64 {
+	return pn.CostToGo + s.epsilon*pn.HeuristicCost
+}
+
+/*
+Cost
+Description:
+
+	Returns the cost of the wrapped planning node with its heuristic inflated by epsilon.
+*/
+func (an *araNode) Cost() float64 {
+	return an.CostToGo + an.epsilon*an.HeuristicCost
+}
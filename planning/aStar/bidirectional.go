@@ -0,0 +1,217 @@
+package aStar
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"math"
+)
+
+/*
+bidirectional.go
+Description:
+
+	Defines how plans are generated with the bidirectional version of
+	the A* algorithm, which searches from the start and from the
+	goal at the same time until the two searches meet.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlanBidirectional
+Description:
+
+	Generates a plan using the bidirectional version of the A* algorithm.
+	To move from node start to node end through the graph g.
+	On directed graphs, the backward search follows edges in reverse.
+
+	forwardHeuristic estimates the cost from a node to end, and
+	backwardHeuristic estimates the cost from start to a node (the
+	planning nodes it receives belong to the backward search).
+	Either may be nil (which is the same as a heuristic of zero).
+
+Notes:
+
+  - The returned plan is optimal as long as both heuristics are consistent.
+*/
+func FindPlanBidirectional(
+	g graph.Weighted,
+	start, end int64,
+	forwardHeuristic, backwardHeuristic func(*PlanningNode) float64,
+) (*planning.Plan, error) {
+	return FindPlanBidirectionalWithContext(
+		context.Background(), g, start, end,
+		forwardHeuristic, backwardHeuristic,
+		planning.Budget{},
+	)
+}
+
+/*
+FindPlanBidirectionalWithContext
+Description:
+
+	Generates a plan like FindPlanBidirectional, but stops early if the
+	context ctx is done or if the search exceeds one of the limits in budget.
+	The statistics count the expansions and heap entries of both searches.
+*/
+func FindPlanBidirectionalWithContext(
+	ctx context.Context,
+	g graph.Weighted,
+	start, end int64,
+	forwardHeuristic, backwardHeuristic func(*PlanningNode) float64,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	if forwardHeuristic == nil {
+		forwardHeuristic = func(*PlanningNode) float64 { return 0.0 }
+	}
+
+	if backwardHeuristic == nil {
+		backwardHeuristic = func(*PlanningNode) float64 { return 0.0 }
+	}
+
+	// Create one search from the start (on g) and one from the end (on the reversed graph)
+	forward := newSearchFrontier(g, start, forwardHeuristic)
+	backward := newSearchFrontier(planning.Reverse(g), end, backwardHeuristic)
+	if start == end {
+		return UnrollPlanFrom(forward.best[start]), nil
+	}
+
+	// The cost of the best plan found so far, and where its two halves meet
+	bestCost := math.Inf(1)
+	var meetForward, meetBackward *PlanningNode
+	stats := gppErrors.SearchStatistics{MaxHeapSize: 2}
+
+	// Algorithm
+	for forward.open.Len() > 0 && backward.open.Len() > 0 {
+		// Stop once no plan through either frontier can beat the best plan
+		// (with consistent heuristics, each Cost() is a lower bound)
+		if forward.open.Min().Cost() >= bestCost || backward.open.Min().Cost() >= bestCost {
+			break
+		}
+
+		// Advance the search with the smaller frontier
+		current, other := forward, backward
+		if backward.open.Len() < forward.open.Len() {
+			current, other = backward, forward
+		}
+
+		// Pop the top node off the heap
+		pn := current.open.PopMin().(*PlanningNode)
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = forward.open.Len() + backward.open.Len()
+		stats.FrontierCost = pn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// Close and expand the node
+		current.closed[pn.NodeID()] = true
+		stats.Expansions++
+
+		for _, newPN := range pn.Expand(current.heuristic) {
+			if !current.relax(newPN) {
+				continue
+			}
+
+			// Check if the two searches meet at the new node
+			otherPN, ok := other.best[newPN.NodeID()]
+			if ok && newPN.CostToGo+otherPN.CostToGo < bestCost {
+				bestCost = newPN.CostToGo + otherPN.CostToGo
+				meetForward, meetBackward = newPN, otherPN
+				if current == backward {
+					meetForward, meetBackward = otherPN, newPN
+				}
+			}
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, forward.open.Len()+backward.open.Len())
+	}
+
+	// Splice the two halves of the plan together
+	if meetForward == nil {
+		return nil, gppErrors.NoPathFound{Graph: g}
+	}
+
+	return UnrollPlanFrom(meetForward).Append(
+		UnrollPlanFrom(meetBackward).Reversed(),
+	), nil
+}
+
+// =======
+// Objects
+// =======
+
+/*
+searchFrontier
+Description:
+
+	The state of one of the two searches in a bidirectional search.
+*/
+type searchFrontier struct {
+	open      *planningHeap.IndexedPlanningHeap
+	best      map[int64]*PlanningNode // The cheapest planning node found for each graph node
+	closed    map[int64]bool
+	heuristic func(*PlanningNode) float64
+}
+
+/*
+newSearchFrontier
+Description:
+
+	Creates a searchFrontier over the graph g that starts at the node with ID source
+	and is guided by the given heuristic.
+*/
+func newSearchFrontier(g graph.Weighted, source int64, heuristic func(*PlanningNode) float64) *searchFrontier {
+	pn0 := &PlanningNode{
+		Graph:            g,
+		CurrentGraphNode: g.Node(source),
+		PreviousInPlan:   nil,
+		CostToGo:         0.0,
+	}
+	pn0.HeuristicCost = pn0.CalculateHeuristicCost(heuristic)
+
+	sf := &searchFrontier{
+		open:      planningHeap.NewIndexedPlanningHeap(),
+		best:      map[int64]*PlanningNode{source: pn0},
+		closed:    make(map[int64]bool),
+		heuristic: heuristic,
+	}
+	sf.open.Push(pn0)
+
+	return sf
+}
+
+/*
+relax
+Description:
+
+	Adds the planning node pn to the frontier if its graph node is not closed
+	and pn is the cheapest way found to reach it so far.
+	Returns true if pn was added.
+*/
+func (sf *searchFrontier) relax(pn *PlanningNode) bool {
+	// Input Processing
+	id := pn.NodeID()
+	if sf.closed[id] {
+		return false
+	}
+
+	if best, ok := sf.best[id]; ok && best.CostToGo <= pn.CostToGo {
+		return false
+	}
+
+	// Algorithm
+	sf.best[id] = pn
+	sf.open.Push(pn)
+	return true
+}
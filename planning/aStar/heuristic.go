@@ -0,0 +1,142 @@
+package aStar
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+heuristic.go
+Description:
+
+	Defines goal-aware heuristics: heuristics that are told which goals
+	the search is heading to, so that one heuristic can be reused for
+	many queries (e.g., in a Planner) and for searches with several goals.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Heuristic
+Description:
+
+	Estimates the cost of reaching the closest of the goals (given by
+	their node IDs) from the node current of the graph g. For A* to
+	return optimal plans, the estimate must never be larger than the
+	cost of the cheapest plan from current to any of the goals.
+*/
+type Heuristic interface {
+	Estimate(g graph.Weighted, current graph.Node, goals []int64) float64
+}
+
+/*
+HeuristicFunc
+Description:
+
+	A function that implements Heuristic.
+	A nil HeuristicFunc estimates every cost as 0.
+*/
+type HeuristicFunc func(g graph.Weighted, current graph.Node, goals []int64) float64
+
+var _ Heuristic = HeuristicFunc(nil)
+
+/*
+funcHeuristic
+Description:
+
+	The Heuristic created by FromFunc, which ignores the goals.
+*/
+type funcHeuristic func(*PlanningNode) float64
+
+// =========
+// Functions
+// =========
+
+/*
+FromFunc
+Description:
+
+	Adapts a heuristic written for FindPlan (which already knows its goal)
+	to the Heuristic interface. The goals given to Estimate are ignored.
+	When the result is passed to one of the planners of this package,
+	the heuristic still receives the full planning node.
+*/
+func FromFunc(heuristic func(*PlanningNode) float64) Heuristic {
+	return funcHeuristic(heuristic)
+}
+
+/*
+Bind
+Description:
+
+	Returns a heuristic for FindPlan (and the other planners that take a
+	func(*PlanningNode) float64) that estimates the cost to the given goals
+	with heuristic. A nil heuristic gives a heuristic of zero.
+*/
+func Bind(heuristic Heuristic, goals ...int64) func(*PlanningNode) float64 {
+	switch h := heuristic.(type) {
+	case nil:
+		return zeroHeuristic
+	case funcHeuristic:
+		if h == nil {
+			return zeroHeuristic
+		}
+		return h
+	case HeuristicFunc:
+		if h == nil {
+			return zeroHeuristic
+		}
+		return func(pn *PlanningNode) float64 {
+			return h(pn.Graph, pn.CurrentGraphNode, goals)
+		}
+	default:
+		return func(pn *PlanningNode) float64 {
+			return h.Estimate(pn.Graph, pn.CurrentGraphNode, goals)
+		}
+	}
+}
+
+/*
+zeroHeuristic
+Description:
+
+	The heuristic that estimates every cost as 0
+	(which makes A* behave like Djikstra's algorithm).
+*/
+func zeroHeuristic(*PlanningNode) float64 {
+	return 0.0
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Estimate
+Description:
+
+	Calls f (or returns 0 if f is nil).
+*/
+func (f HeuristicFunc) Estimate(g graph.Weighted, current graph.Node, goals []int64) float64 {
+	if f == nil {
+		return 0.0
+	}
+
+	return f(g, current, goals)
+}
+
+/*
+Estimate
+Description:
+
+	Calls the adapted heuristic with a planning node for current
+	(whose costs and predecessor are unknown).
+*/
+func (f funcHeuristic) Estimate(g graph.Weighted, current graph.Node, _ []int64) float64 {
+	if f == nil {
+		return 0.0
+	}
+
+	return f(&PlanningNode{Graph: g, CurrentGraphNode: current})
+}
@@ -1,8 +1,9 @@
 package aStar
 
 import (
-	"container/heap"
+	"context"
 	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
 	"github.com/GraphPathPlanning.go/planningHeap"
 	"gonum.org/v1/gonum/graph"
 	"slices"
@@ -19,10 +20,14 @@ Description:
 // Type Definitions
 // ================
 
-type Plan struct {
-	Sequence []graph.Node // The sequence of nodes in the path (start @ 0, and end @ len(Sequence) - 1
-	CostToGo float64
-}
+/*
+Plan
+Description:
+
+	The plans returned by this package (an alias of planning.Plan,
+	kept so that code written against the old Plan type still compiles).
+*/
+type Plan = planning.Plan
 
 // =======
 // Functions
@@ -35,13 +40,108 @@ Description:
 	Generates a plan using the A* algorithm.
 	To move from node start to node end through the graph
 	g.
+
+Notes:
+
+  - Each graph node is expanded at most once. The returned plan
+    is optimal as long as the heuristic is consistent.
 */
 func FindPlan(
-	g graph.WeightedUndirected,
+	g graph.Weighted,
+	start, end int64,
+	heuristic func(*PlanningNode) float64,
+) (*planning.Plan, error) {
+	return FindPlanWithContext(context.Background(), g, start, end, heuristic, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates a plan using the A* algorithm, like FindPlan,
+	but stops early if the context ctx is done or if the search
+	exceeds one of the limits in budget.
+
+Notes:
+
+  - When the search is stopped early, the error is a
+    gppErrors.SearchCancelled or a gppErrors.SearchBudgetExceeded
+    containing the statistics of the partial search.
+  - If start or end is not in the graph, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindPlanWithContext(
+	ctx context.Context,
+	g graph.Weighted,
 	start, end int64,
 	heuristic func(*PlanningNode) float64,
-) (*Plan, error) {
-	// Constants
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	return FindPlanToGoalsWithContext(ctx, g, start, []int64{end}, FromFunc(heuristic), budget)
+}
+
+/*
+FindPlanToGoals
+Description:
+
+	Generates a plan using the A* algorithm, from node start to
+	whichever of the nodes in goals is the cheapest to reach.
+	The heuristic is told the goals, so the same heuristic can be used
+	for every query (a nil heuristic is the same as a heuristic of zero).
+
+Notes:
+
+  - The returned plan is optimal as long as the heuristic is consistent
+    (e.g., the smallest of consistent estimates to each goal).
+  - If goals is empty, the error is a gppErrors.NoPathFound.
+*/
+func FindPlanToGoals(
+	g graph.Weighted,
+	start int64,
+	goals []int64,
+	heuristic Heuristic,
+) (*planning.Plan, error) {
+	return FindPlanToGoalsWithContext(context.Background(), g, start, goals, heuristic, planning.Budget{})
+}
+
+/*
+FindPlanToGoalsWithContext
+Description:
+
+	Generates a plan like FindPlanToGoals, but stops early if the
+	context ctx is done or if the search exceeds one of the limits in budget.
+
+Notes:
+
+  - If start or one of the goals is not in the graph, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindPlanToGoalsWithContext(
+	ctx context.Context,
+	g graph.Weighted,
+	start int64,
+	goals []int64,
+	heuristic Heuristic,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	// Input Processing
+	if !planning.HasNode(g, start) {
+		return nil, gppErrors.StartNodeNotFound{ID: start}
+	}
+
+	isGoal := make(map[int64]bool, len(goals))
+	for _, goal := range goals {
+		if err := planning.CheckEndpoints(g, start, goal); err != nil {
+			return nil, err
+		}
+		isGoal[goal] = true
+	}
+
+	if len(goals) == 0 {
+		return nil, gppErrors.NoPathFound{Graph: g}
+	}
+
+	estimate := Bind(heuristic, goals...)
 
 	// Create initial planning node and heap
 	pn0 := &PlanningNode{
@@ -52,31 +152,57 @@ func FindPlan(
 		HeuristicCost:    0.0,
 	}
 
-	var heap0 planningHeap.PlanningHeap
-	heap.Init(&heap0)
-	heap.Push(&heap0, pn0)
+	open := planningHeap.NewIndexedPlanningHeap()
+	open.Push(pn0)
+
+	// Track the nodes that have already been expanded (i.e., the closed set).
+	// The open heap holds at most one (the cheapest) planning node per graph node.
+	closed := make(map[int64]bool)
+	stats := gppErrors.SearchStatistics{MaxHeapSize: open.Len()}
 
 	// Algorithm
-	for len(heap0) > 0 {
+	for open.Len() > 0 {
 		// Pop the top node off the heap
-		pn := heap.Pop(&heap0).(*PlanningNode)
+		pn := open.PopMin().(*PlanningNode)
+		currentID := pn.NodeID()
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = open.Len()
+		stats.FrontierCost = pn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
 
-		// If we have reached the end, return the plan
-		if pn.CurrentGraphNode.ID() == end {
+		// If we have reached a goal, return the plan
+		if isGoal[currentID] {
 			return UnrollPlanFrom(pn), nil
 		}
 
-		// Otherwise, expand the node
-		expandedNodes := pn.Expand(heuristic)
+		// Otherwise, close and expand the node
+		closed[currentID] = true
+		expandedNodes := pn.Expand(estimate)
+		stats.Expansions++
 
-		// Add the expanded nodes to the heap
+		// Add the expanded nodes to the heap, or lower the cost of
+		// their existing entries if we found a cheaper route
 		for _, newPN := range expandedNodes {
-			heap.Push(&heap0, newPN)
+			newID := newPN.NodeID()
+			if closed[newID] {
+				continue
+			}
+
+			if !open.Contains(newID) {
+				open.Push(newPN)
+				continue
+			}
+
+			open.DecreaseKey(newPN)
 		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, open.Len())
 
 	}
 
-	return nil, gppErrors.NoPathFound{g}
+	return nil, gppErrors.NoPathFound{Graph: g}
 
 }
 
@@ -88,7 +214,7 @@ Description:
 	Hopefully, you try this only on a planning node
 	that reaches the end.
 */
-func UnrollPlanFrom(pn *PlanningNode) *Plan {
+func UnrollPlanFrom(pn *PlanningNode) *planning.Plan {
 	// Check to see if plan is empty
 	if pn == nil {
 		return nil
@@ -97,6 +223,7 @@ func UnrollPlanFrom(pn *PlanningNode) *Plan {
 	// Iterate through each of the nodes in the plan
 	current := pn
 	var reversedPlan []graph.Node
+	var reversedEdgeCosts []float64
 	for current != nil {
 		// Add current node (in graph) to plan
 		reversedPlan = append(
@@ -104,6 +231,14 @@ func UnrollPlanFrom(pn *PlanningNode) *Plan {
 			current.Graph.Node(current.CurrentGraphNode.ID()),
 		)
 
+		// Add the cost of the edge that led to the current node
+		if current.PreviousInPlan != nil {
+			reversedEdgeCosts = append(
+				reversedEdgeCosts,
+				current.CostToGo-current.PreviousInPlan.CostToGo,
+			)
+		}
+
 		// Update current
 		current = current.PreviousInPlan
 	}
@@ -112,8 +247,12 @@ func UnrollPlanFrom(pn *PlanningNode) *Plan {
 	forwardPlan := reversedPlan
 	slices.Reverse(forwardPlan)
 
-	return &Plan{
-		Sequence: forwardPlan,
-		CostToGo: pn.CostToGo,
+	forwardEdgeCosts := reversedEdgeCosts
+	slices.Reverse(forwardEdgeCosts)
+
+	return &planning.Plan{
+		Sequence:  forwardPlan,
+		EdgeCosts: forwardEdgeCosts,
+		CostToGo:  pn.CostToGo,
 	}
 }
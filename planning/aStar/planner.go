@@ -0,0 +1,62 @@
+package aStar
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planner.go
+Description:
+
+	Adapts the A* algorithm to the planning.Planner interface.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Planner
+Description:
+
+	A planning.Planner that uses the A* algorithm.
+	If GoalHeuristic is set, it estimates the cost to the goal of each
+	query; otherwise Heuristic is used. If both are nil, then a heuristic
+	of zero is used (which makes the search behave like Djikstra's algorithm).
+	The search is limited by Budget (the zero value means no limits).
+*/
+type Planner struct {
+	Heuristic     func(*PlanningNode) float64
+	GoalHeuristic Heuristic
+	Budget        planning.Budget
+}
+
+var _ planning.Planner = Planner{}
+
+// =======
+// Methods
+// =======
+
+/*
+Plan
+Description:
+
+	Generates a plan from node start to node goal through the graph g
+	using the A* algorithm.
+*/
+func (p Planner) Plan(
+	ctx context.Context,
+	g graph.Weighted,
+	start, goal int64,
+) (*planning.Plan, error) {
+	// Input Processing
+	heuristic := p.GoalHeuristic
+	if heuristic == nil {
+		heuristic = FromFunc(p.Heuristic)
+	}
+
+	// Algorithm
+	return FindPlanToGoalsWithContext(ctx, g, start, []int64{goal}, heuristic, p.Budget)
+}
@@ -0,0 +1,200 @@
+package alt
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+landmarks.go
+Description:
+
+	Defines the landmark distance tables of ALT (A*, Landmarks and the
+	Triangle inequality). For any landmark L and nodes v and t, the
+	triangle inequality gives two lower bounds on the distance from v to t:
+
+		d(v, t) >= d(L, t) - d(L, v)   and   d(v, t) >= d(v, L) - d(t, L),
+
+	so the largest of these bounds over all landmarks is a heuristic that
+	never overestimates and is consistent, on any graph with non-negative
+	edge weights.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+Landmarks
+Description:
+
+	A set of landmarks and the distances between every landmark and every
+	node of a graph, in both directions. Create them with Build (which
+	also selects the landmarks) or New, then use Heuristic with
+	aStar.FindPlan. The tables are not updated when the graph changes,
+	so they must be rebuilt if a weight decreases (raising a weight
+	keeps the bounds valid, only weaker).
+*/
+type Landmarks struct {
+	ids  []int64
+	from []map[int64]float64 // from[i][v] is the distance from landmark i to v
+	to   []map[int64]float64 // to[i][v] is the distance from v to landmark i
+}
+
+// =========
+// Functions
+// =========
+
+/*
+New
+Description:
+
+	Computes the distance tables of the landmarks with the given IDs
+	in the graph g (two runs of Djikstra's algorithm per landmark,
+	or one on undirected graphs), and stops early if the context ctx is done.
+
+Notes:
+
+  - If a landmark is not in the graph, the error is a gppErrors.NodeNotFound.
+  - If an edge has a negative weight, the error is a gppErrors.UnsupportedGraph.
+*/
+func New(ctx context.Context, g graph.Weighted, ids ...int64) (*Landmarks, error) {
+	// Algorithm
+	l := &Landmarks{}
+	for _, id := range ids {
+		if err := l.add(ctx, g, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// =======
+// Methods
+// =======
+
+/*
+add
+Description:
+
+	Adds the node with ID id of the graph g as a landmark
+	and computes its distance tables.
+*/
+func (l *Landmarks) add(ctx context.Context, g graph.Weighted, id int64) error {
+	// Input Processing
+	if !planning.HasNode(g, id) {
+		return gppErrors.NodeNotFound{ID: id, Role: "landmark"}
+	}
+
+	// Algorithm
+	fromTree, err := planning.ShortestPaths(ctx, g, id)
+	if err != nil {
+		return err
+	}
+
+	toTree := fromTree
+	if _, directed := g.(graph.Directed); directed {
+		toTree, err = planning.ShortestPaths(ctx, planning.Reverse(g), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	l.ids = append(l.ids, id)
+	l.from = append(l.from, fromTree.Distances)
+	l.to = append(l.to, toTree.Distances)
+	return nil
+}
+
+/*
+IDs
+Description:
+
+	Returns the IDs of the landmarks.
+*/
+func (l *Landmarks) IDs() []int64 {
+	return slices.Clone(l.ids)
+}
+
+/*
+Estimate
+Description:
+
+	Returns a lower bound on the cost of moving from the node with ID
+	from to the node with ID to. The bound is +Inf if the landmarks prove
+	that there is no such path, and 0 if they tell nothing about it.
+*/
+func (l *Landmarks) Estimate(from, to int64) float64 {
+	estimate := 0.0
+	for idx := range l.ids {
+		// d(from, to) >= d(L, to) - d(L, from) (no bound if L cannot reach from)
+		if dFrom, ok := l.from[idx][from]; ok {
+			estimate = max(estimate, distanceOrInf(l.from[idx], to)-dFrom)
+		}
+
+		// d(from, to) >= d(from, L) - d(to, L) (no bound if to cannot reach L)
+		if dTo, ok := l.to[idx][to]; ok {
+			estimate = max(estimate, distanceOrInf(l.to[idx], from)-dTo)
+		}
+	}
+
+	return estimate
+}
+
+/*
+Heuristic
+Description:
+
+	Returns a heuristic for aStar.FindPlan (and the other A* planners)
+	that estimates the cost to the node with ID goal with Estimate.
+	The heuristic is admissible and consistent, so A* still returns
+	optimal plans.
+*/
+func (l *Landmarks) Heuristic(goal int64) func(*aStar.PlanningNode) float64 {
+	return func(pn *aStar.PlanningNode) float64 {
+		return l.Estimate(pn.CurrentGraphNode.ID(), goal)
+	}
+}
+
+/*
+ToNearestGoal
+Description:
+
+	Returns a goal-aware heuristic (see aStar.Heuristic) that estimates
+	the cost to the nearest goal as the smallest of the estimates to each
+	goal, so that the same heuristic can be used for every query.
+*/
+func (l *Landmarks) ToNearestGoal() aStar.Heuristic {
+	return aStar.HeuristicFunc(func(_ graph.Weighted, current graph.Node, goals []int64) float64 {
+		nearest := math.Inf(1)
+		for _, goal := range goals {
+			nearest = min(nearest, l.Estimate(current.ID(), goal))
+		}
+
+		if len(goals) == 0 {
+			return 0.0
+		}
+		return nearest
+	})
+}
+
+/*
+distanceOrInf
+Description:
+
+	Returns the distance to the node with ID id in the table distances
+	(+Inf if the node was not reached).
+*/
+func distanceOrInf(distances map[int64]float64, id int64) float64 {
+	if d, ok := distances[id]; ok {
+		return d
+	}
+
+	return math.Inf(1)
+}
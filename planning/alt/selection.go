@@ -0,0 +1,230 @@
+package alt
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+/*
+selection.go
+Description:
+
+	Defines how landmarks are selected. Good landmarks lie "behind" the
+	start or the goal of many queries, which usually means at the border
+	of the graph and far away from each other.
+*/
+
+// =========
+// Constants
+// =========
+
+const DefaultLandmarkCount = 8
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Strategy
+Description:
+
+	The way Build selects landmarks.
+*/
+type Strategy int
+
+const (
+	// Farthest selects each landmark as far as possible from the
+	// landmarks selected before it (the first one is as far as possible
+	// from the node with the smallest ID).
+	Farthest Strategy = iota
+
+	// Avoid selects the first landmark like Farthest, and then grows a
+	// shortest-path tree from a random root, weighs each node by how much
+	// the current landmarks underestimate its distance from the root, and
+	// selects a leaf of the subtree with the largest weight that does not
+	// contain a landmark yet (Goldberg and Werneck, 2005). It usually gives
+	// better bounds than Farthest for the same number of landmarks.
+	Avoid
+)
+
+/*
+Options
+Description:
+
+	The settings of Build.
+	Count is the number of landmarks (DefaultLandmarkCount if 0, and at
+	most the number of nodes), and Seed chooses the random roots of Avoid.
+*/
+type Options struct {
+	Count    int
+	Strategy Strategy
+	Seed     int64
+}
+
+// =========
+// Functions
+// =========
+
+/*
+Build
+Description:
+
+	Selects landmarks of the graph g with the strategy in options and
+	computes their distance tables (see New). Stops early (with a
+	gppErrors.SearchCancelled error) if the context ctx is done.
+*/
+func Build(ctx context.Context, g graph.Weighted, options Options) (*Landmarks, error) {
+	// Input Processing
+	count := options.Count
+	if count == 0 {
+		count = DefaultLandmarkCount
+	}
+
+	var ids []int64
+	nodes := g.Nodes()
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	slices.Sort(ids)
+
+	// Algorithm
+	l := &Landmarks{}
+	rng := rand.New(rand.NewSource(options.Seed))
+	for len(l.ids) < min(count, len(ids)) {
+		next, found := int64(0), false
+		if options.Strategy == Avoid && len(l.ids) > 0 {
+			var err error
+			next, found, err = l.avoidCandidate(ctx, g, ids[rng.Intn(len(ids))])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Fall back to the farthest node (e.g., when every tree already contains a landmark)
+		if !found {
+			var err error
+			next, err = l.farthestCandidate(ctx, g, ids)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := l.add(ctx, g, next); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// =======
+// Methods
+// =======
+
+/*
+farthestCandidate
+Description:
+
+	Returns the node of g that is farthest from the current landmarks
+	(or from the first of ids if there are none yet). Nodes that cannot
+	be reached count as infinitely far away, so that every part of
+	the graph gets a landmark.
+*/
+func (l *Landmarks) farthestCandidate(ctx context.Context, g graph.Weighted, ids []int64) (int64, error) {
+	// Setup
+	sources := l.ids
+	if len(sources) == 0 {
+		sources = ids[:1]
+	}
+
+	tree, err := planning.ShortestPaths(ctx, g, sources...)
+	if err != nil {
+		return 0, err
+	}
+
+	// Algorithm
+	best, bestDistance := int64(0), math.Inf(-1)
+	for _, id := range ids {
+		if slices.Contains(l.ids, id) {
+			continue
+		}
+
+		if d := distanceOrInf(tree.Distances, id); d > bestDistance {
+			best, bestDistance = id, d
+		}
+	}
+
+	return best, nil
+}
+
+/*
+avoidCandidate
+Description:
+
+	Returns the next landmark chosen by the Avoid strategy from the
+	shortest-path tree of the node with ID root, and false if every
+	subtree whose distances the landmarks underestimate contains a landmark.
+*/
+func (l *Landmarks) avoidCandidate(ctx context.Context, g graph.Weighted, root int64) (int64, bool, error) {
+	// Setup
+	tree, err := planning.ShortestPaths(ctx, g, root)
+	if err != nil {
+		return 0, false, err
+	}
+
+	children := make(map[int64][]int64)
+	for child, parent := range tree.Parents {
+		children[parent] = append(children[parent], child)
+	}
+	for _, c := range children {
+		slices.Sort(c)
+	}
+
+	// Weigh every subtree by how much its distances are underestimated,
+	// visiting children before their parents
+	sizes := make(map[int64]float64, len(tree.Distances))
+	hasLandmark := make(map[int64]bool)
+	var postOrder []int64
+	stack := []int64{root}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		postOrder = append(postOrder, id)
+		stack = append(stack, children[id]...)
+	}
+	slices.Reverse(postOrder)
+
+	for _, id := range postOrder {
+		size := tree.Distances[id] - l.Estimate(root, id)
+		landmark := slices.Contains(l.ids, id)
+		for _, child := range children[id] {
+			size += sizes[child]
+			landmark = landmark || hasLandmark[child]
+		}
+
+		hasLandmark[id] = landmark
+		if !landmark {
+			sizes[id] = size
+		}
+	}
+
+	// Walk down from the root to a leaf, always into the heaviest subtree
+	current := root
+	for {
+		next, nextSize := int64(0), 0.0
+		for _, child := range children[current] {
+			if sizes[child] > nextSize {
+				next, nextSize = child, sizes[child]
+			}
+		}
+
+		if nextSize <= 0.0 {
+			return current, current != root, nil
+		}
+		current = next
+	}
+}
@@ -0,0 +1,91 @@
+package planning
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+)
+
+/*
+budget.go
+Description:
+
+	Defines the limits that can be placed on a search so that
+	planning latency stays bounded (e.g., on large, disconnected graphs).
+*/
+
+// =========
+// Constants
+// =========
+
+const (
+	MaxExpansionsBudget = "max expansions"
+	MaxHeapSizeBudget   = "max heap size"
+	MaxCostBudget       = "max cost"
+)
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Budget
+Description:
+
+	The limits of a search. A value of zero means that there is no limit.
+*/
+type Budget struct {
+	MaxExpansions int     // The maximum number of graph nodes to expand
+	MaxHeapSize   int     // The maximum number of planning nodes waiting in the heap
+	MaxCost       float64 // The maximum cost of a planning node popped off the heap
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Check
+Description:
+
+	Returns a gppErrors.SearchCancelled error if the context is done,
+	a gppErrors.SearchBudgetExceeded error if the statistics stats
+	exceed one of the limits in the budget, and nil otherwise.
+
+Notes:
+
+  - Planners call this after popping a node (with its cost in
+    stats.FrontierCost) and before expanding it.
+*/
+func (b Budget) Check(ctx context.Context, stats gppErrors.SearchStatistics) error {
+	// Check context
+	if err := ctx.Err(); err != nil {
+		return gppErrors.SearchCancelled{Cause: err, Statistics: stats}
+	}
+
+	// Check budgets
+	if b.MaxExpansions > 0 && stats.Expansions >= b.MaxExpansions {
+		return gppErrors.SearchBudgetExceeded{
+			Budget:     MaxExpansionsBudget,
+			Limit:      float64(b.MaxExpansions),
+			Statistics: stats,
+		}
+	}
+
+	if b.MaxHeapSize > 0 && stats.HeapSize > b.MaxHeapSize {
+		return gppErrors.SearchBudgetExceeded{
+			Budget:     MaxHeapSizeBudget,
+			Limit:      float64(b.MaxHeapSize),
+			Statistics: stats,
+		}
+	}
+
+	if b.MaxCost > 0 && stats.FrontierCost > b.MaxCost {
+		return gppErrors.SearchBudgetExceeded{
+			Budget:     MaxCostBudget,
+			Limit:      b.MaxCost,
+			Statistics: stats,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package contractionHierarchies
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"slices"
+)
+
+/*
+build.go
+Description:
+
+	Defines how a contraction hierarchy is built: the nodes are ordered
+	by how many shortcuts contracting them would add (the edge difference),
+	how many of their neighbors are already contracted and how deep the
+	hierarchy below them is, and each contraction adds the shortcuts
+	that no witness path makes unnecessary.
+*/
+
+// =========
+// Constants
+// =========
+
+const DefaultWitnessSettleLimit = 500
+
+// ================
+// Type Definitions
+// ================
+
+/*
+BuildOptions
+Description:
+
+	The settings of BuildWithContext.
+	WitnessSettleLimit is the largest number of nodes that one witness
+	search may settle (DefaultWitnessSettleLimit if 0). Smaller limits
+	build faster but may add shortcuts that are not needed (which never
+	makes the hierarchy wrong, only larger).
+*/
+type BuildOptions struct {
+	WitnessSettleLimit int
+}
+
+// =========
+// Functions
+// =========
+
+/*
+Build
+Description:
+
+	Builds the contraction hierarchy of the graph g with the default options.
+	Every edge weight must be non-negative, and the graph must not change
+	while the hierarchy is in use.
+*/
+func Build(g graph.Weighted) (*Hierarchy, error) {
+	return BuildWithContext(context.Background(), g, BuildOptions{})
+}
+
+/*
+BuildWithContext
+Description:
+
+	Builds the contraction hierarchy of the graph g like Build,
+	but stops early (with a gppErrors.SearchCancelled error)
+	if the context ctx is done.
+
+Notes:
+
+  - If an edge has a negative weight, the error is a gppErrors.UnsupportedGraph.
+*/
+func BuildWithContext(ctx context.Context, g graph.Weighted, options BuildOptions) (*Hierarchy, error) {
+	// Input Processing
+	settleLimit := options.WitnessSettleLimit
+	if settleLimit == 0 {
+		settleLimit = DefaultWitnessSettleLimit
+	}
+
+	c, err := newContractor(g, settleLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order the nodes by their initial priority (in the same way every time)
+	ids := make([]int64, 0, len(c.out))
+	for id := range c.out {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	queue := planningHeap.NewIndexedPlanningHeap()
+	for _, id := range ids {
+		queue.Push(&label{id: id, cost: c.priority(id)})
+	}
+
+	// Algorithm
+	order := make([]int64, 0, len(c.out))
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, gppErrors.SearchCancelled{Cause: err}
+		}
+
+		// Lazy updates: contract the node only if its priority is still the smallest
+		next := queue.PopMin().(*label)
+		if p := c.priority(next.id); queue.Len() > 0 && p > queue.Min().Cost() {
+			queue.Push(&label{id: next.id, cost: p})
+			continue
+		}
+
+		// Contract it and update the priorities of its neighbors
+		for _, neighbor := range c.contract(next.id) {
+			queue.Update(&label{id: neighbor, cost: c.priority(neighbor)})
+		}
+		order = append(order, next.id)
+	}
+
+	return newHierarchy(g, order, c.arcs), nil
+}
+
+// =======
+// Objects
+// =======
+
+/*
+contractor
+Description:
+
+	The graph that remains while nodes are being contracted.
+*/
+type contractor struct {
+	out         map[int64]map[int64]float64 // out[u][v] is the weight of the remaining arc u -> v
+	in          map[int64]map[int64]float64 // in[v][u] is the weight of the remaining arc u -> v
+	arcs        map[[2]int64]arc            // Every arc added so far (original edges and shortcuts)
+	contracted  map[int64]int               // The number of contracted neighbors of each node
+	depth       map[int64]int               // The length of the longest chain of contracted nodes below each node
+	settleLimit int
+}
+
+/*
+newContractor
+Description:
+
+	Copies the nodes and edges of g (keeping the lightest of parallel edges).
+*/
+func newContractor(g graph.Weighted, settleLimit int) (*contractor, error) {
+	c := &contractor{
+		out:         make(map[int64]map[int64]float64),
+		in:          make(map[int64]map[int64]float64),
+		arcs:        make(map[[2]int64]arc),
+		contracted:  make(map[int64]int),
+		depth:       make(map[int64]int),
+		settleLimit: settleLimit,
+	}
+
+	nodes := g.Nodes()
+	for nodes.Next() {
+		id := nodes.Node().ID()
+		c.out[id] = make(map[int64]float64)
+		c.in[id] = make(map[int64]float64)
+	}
+
+	for from := range c.out {
+		neighbors := g.From(from)
+		for neighbors.Next() {
+			to := neighbors.Node().ID()
+			w, ok := g.Weight(from, to)
+			if !ok || from == to {
+				continue
+			}
+
+			if w < 0.0 {
+				return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "contraction hierarchies need non-negative edge weights"}
+			}
+			c.addArc(from, to, arc{To: to, Weight: w})
+		}
+	}
+
+	return c, nil
+}
+
+/*
+addArc
+Description:
+
+	Adds the arc a from the node with ID from, unless a lighter
+	arc between the same nodes already exists.
+*/
+func (c *contractor) addArc(from, to int64, a arc) {
+	if existing, ok := c.out[from][to]; ok && existing <= a.Weight {
+		return
+	}
+
+	c.out[from][to] = a.Weight
+	c.in[to][from] = a.Weight
+	c.arcs[[2]int64{from, to}] = a
+}
+
+/*
+priority
+Description:
+
+	Returns the priority of contracting the node with ID v next (smaller
+	is sooner): the number of shortcuts it needs minus the number of arcs
+	it removes, plus the number of its neighbors that are already contracted
+	and the depth of the hierarchy below it (which keeps the hierarchy flat,
+	so that queries stay small).
+*/
+func (c *contractor) priority(v int64) float64 {
+	nShortcuts := len(c.shortcutsFor(v))
+	removed := len(c.in[v]) + len(c.out[v])
+	return float64(nShortcuts-removed) + float64(c.contracted[v]) + float64(c.depth[v])
+}
+
+/*
+contract
+Description:
+
+	Removes the node with ID v from the remaining graph,
+	adding the shortcuts between its neighbors that it needs.
+	Returns the IDs of its neighbors.
+*/
+func (c *contractor) contract(v int64) []int64 {
+	// Add the shortcuts
+	for _, s := range c.shortcutsFor(v) {
+		c.addArc(s.from, s.To, s.arc)
+	}
+
+	// Remove the node
+	neighbors := make(map[int64]bool)
+	for u := range c.in[v] {
+		delete(c.out[u], v)
+		neighbors[u] = true
+	}
+	for w := range c.out[v] {
+		delete(c.in[w], v)
+		neighbors[w] = true
+	}
+	delete(c.out, v)
+	delete(c.in, v)
+
+	ids := make([]int64, 0, len(neighbors))
+	for id := range neighbors {
+		c.contracted[id]++
+		c.depth[id] = max(c.depth[id], c.depth[v]+1)
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+/*
+shortcut
+Description:
+
+	A shortcut that contracting a node would add.
+*/
+type shortcut struct {
+	arc
+	from int64
+}
+
+/*
+shortcutsFor
+Description:
+
+	Returns the shortcuts needed to contract the node with ID v: for every
+	pair of neighbors u -> v -> w, a shortcut u -> w unless a witness
+	path from u to w that avoids v is at most as long.
+*/
+func (c *contractor) shortcutsFor(v int64) []shortcut {
+	var shortcuts []shortcut
+	for u, wIn := range c.in[v] {
+		// Find the targets and the longest path through v that we need to beat
+		maxDistance := 0.0
+		for w, wOut := range c.out[v] {
+			if w != u {
+				maxDistance = max(maxDistance, wIn+wOut)
+			}
+		}
+
+		distances := c.witnessSearch(u, v, maxDistance, c.out[v])
+		for w, wOut := range c.out[v] {
+			if w == u {
+				continue
+			}
+
+			through := wIn + wOut
+			if d, ok := distances[w]; ok && d <= through {
+				continue
+			}
+			shortcuts = append(shortcuts, shortcut{from: u, arc: arc{To: w, Weight: through, Middle: v, Shortcut: true}})
+		}
+	}
+
+	return shortcuts
+}
+
+/*
+witnessSearch
+Description:
+
+	Returns the distances from the node with ID source to the nodes that a
+	search of the remaining graph without the node with ID avoid reaches
+	before passing maxDistance or the settle limit, or before it has
+	settled all of the targets.
+*/
+func (c *contractor) witnessSearch(source, avoid int64, maxDistance float64, targets map[int64]float64) map[int64]float64 {
+	// Setup
+	distances := map[int64]float64{source: 0.0}
+	open := planningHeap.NewIndexedPlanningHeap()
+	open.Push(&label{id: source, cost: 0.0})
+	settled, settledTargets := 0, 0
+
+	// Algorithm
+	for open.Len() > 0 && settled < c.settleLimit && settledTargets < len(targets) {
+		current := open.PopMin().(*label)
+		if current.cost > maxDistance {
+			break
+		}
+		settled++
+		if _, ok := targets[current.id]; ok {
+			settledTargets++
+		}
+
+		for next, w := range c.out[current.id] {
+			if next == avoid {
+				continue
+			}
+
+			candidate := current.cost + w
+			if d, ok := distances[next]; ok && d <= candidate {
+				continue
+			}
+			distances[next] = candidate
+
+			if open.Contains(next) {
+				open.DecreaseKey(&label{id: next, cost: candidate})
+				continue
+			}
+			open.Push(&label{id: next, cost: candidate})
+		}
+	}
+
+	// Distances that were not settled are still the lengths of real paths,
+	// which is all a witness needs
+	return distances
+}
@@ -0,0 +1,47 @@
+package contractionHierarchies
+
+import "fmt"
+
+/*
+errors.go
+Description:
+
+	Defines the errors returned when reading a serialized hierarchy.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+UnsupportedVersionError
+Description:
+
+	Returned when a serialized hierarchy was written in a format version
+	that this package cannot read.
+*/
+type UnsupportedVersionError struct {
+	Version int
+}
+
+func (e UnsupportedVersionError) Error() string {
+	return fmt.Sprintf(
+		"serialized hierarchy has format version %v, but only version %v is supported",
+		e.Version, formatVersion,
+	)
+}
+
+/*
+CorruptHierarchyError
+Description:
+
+	Returned when a serialized hierarchy is not consistent
+	(e.g., an arc leads to a node that has no rank).
+*/
+type CorruptHierarchyError struct {
+	Reason string
+}
+
+func (e CorruptHierarchyError) Error() string {
+	return fmt.Sprintf("serialized hierarchy is corrupt: %v", e.Reason)
+}
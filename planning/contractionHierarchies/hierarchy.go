@@ -0,0 +1,143 @@
+package contractionHierarchies
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+/*
+hierarchy.go
+Description:
+
+	Defines the contraction hierarchy built from a weighted graph.
+
+	A contraction hierarchy ranks the nodes of a graph and "contracts"
+	them one by one, from the least to the most important: when a node is
+	removed, shortcut edges are added between its neighbors wherever the
+	node was on their only shortest path (which a witness search checks).
+	Afterwards, every shortest path can be found by searching only "up"
+	the ranking from both ends, which visits a tiny part of the graph.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+Hierarchy
+Description:
+
+	A contraction hierarchy: the rank of each node and the edges
+	(original edges and shortcuts) that lead up the ranking.
+	Create one with Build (or load one with Read), then answer queries
+	with FindPlan. A Hierarchy can be used by several goroutines at once.
+*/
+type Hierarchy struct {
+	graph graph.Graph      // The graph that provides the nodes of plans (may be nil)
+	order []int64          // The node IDs in the order they were contracted
+	rank  map[int64]int    // The position of each node in order
+	up    map[int64][]arc  // up[u] lists the arcs u -> v with rank(v) > rank(u)
+	down  map[int64][]arc  // down[u] lists the arcs v -> u with rank(v) > rank(u) (with To = v)
+	arcs  map[[2]int64]arc // Every arc by its ends, to unpack shortcuts
+}
+
+/*
+arc
+Description:
+
+	An edge of the hierarchy. A shortcut replaces the path from its tail
+	to Middle and from Middle to its head.
+*/
+type arc struct {
+	To       int64
+	Weight   float64
+	Middle   int64
+	Shortcut bool
+}
+
+// =========
+// Functions
+// =========
+
+/*
+newHierarchy
+Description:
+
+	Creates a hierarchy with the given contraction order and arcs,
+	where arcs maps each {from, to} pair to its arc.
+*/
+func newHierarchy(g graph.Graph, order []int64, arcs map[[2]int64]arc) *Hierarchy {
+	h := &Hierarchy{
+		graph: g,
+		order: order,
+		rank:  make(map[int64]int, len(order)),
+		up:    make(map[int64][]arc),
+		down:  make(map[int64][]arc),
+		arcs:  arcs,
+	}
+	for idx, id := range order {
+		h.rank[id] = idx
+	}
+
+	for ends, a := range arcs {
+		from, to := ends[0], ends[1]
+		if h.rank[to] > h.rank[from] {
+			h.up[from] = append(h.up[from], a)
+			continue
+		}
+		h.down[to] = append(h.down[to], arc{To: from, Weight: a.Weight, Middle: a.Middle, Shortcut: a.Shortcut})
+	}
+
+	return h
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Rank
+Description:
+
+	Returns the position of the node with ID id in the contraction order
+	(0 for the first node to be contracted), and false if the node
+	is not in the hierarchy.
+*/
+func (h *Hierarchy) Rank(id int64) (int, bool) {
+	r, ok := h.rank[id]
+	return r, ok
+}
+
+/*
+Shortcuts
+Description:
+
+	Returns the number of shortcut edges that were added to the graph.
+*/
+func (h *Hierarchy) Shortcuts() int {
+	count := 0
+	for _, a := range h.arcs {
+		if a.Shortcut {
+			count++
+		}
+	}
+
+	return count
+}
+
+/*
+node
+Description:
+
+	Returns the graph node with ID id (a simple.Node if the
+	hierarchy has no graph).
+*/
+func (h *Hierarchy) node(id int64) graph.Node {
+	if h.graph != nil {
+		if n := h.graph.Node(id); n != nil {
+			return n
+		}
+	}
+
+	return simple.Node(id)
+}
@@ -0,0 +1,40 @@
+package contractionHierarchies
+
+import "github.com/GraphPathPlanning.go/planningHeap"
+
+/*
+label.go
+Description:
+
+	Defines the heap entries used by the searches of this package.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+label
+Description:
+
+	A node and its tentative cost (or its contraction priority)
+	in one of the searches of this package.
+*/
+type label struct {
+	id   int64
+	cost float64
+}
+
+var _ planningHeap.IndexedPlanningNode = &label{}
+
+// =======
+// Methods
+// =======
+
+func (l *label) Cost() float64 {
+	return l.cost
+}
+
+func (l *label) NodeID() int64 {
+	return l.id
+}
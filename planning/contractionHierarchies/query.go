@@ -0,0 +1,262 @@
+package contractionHierarchies
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"math"
+)
+
+/*
+query.go
+Description:
+
+	Defines how shortest plans are found with a contraction hierarchy:
+	a forward search from the start and a backward search from the goal
+	that both only move up the ranking, followed by the unpacking of the
+	shortcuts on the best plan where they meet.
+*/
+
+// =======
+// Methods
+// =======
+
+/*
+FindPlan
+Description:
+
+	Generates the shortest plan from node start to node end through the
+	graph that the hierarchy was built from. The plan only contains
+	original nodes and edges (every shortcut is unpacked).
+*/
+func (h *Hierarchy) FindPlan(start, end int64) (*planning.Plan, error) {
+	return h.FindPlanWithContext(context.Background(), start, end, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates a plan like FindPlan, but stops early if the context ctx
+	is done or if the search exceeds one of the limits in budget.
+	The statistics count the expansions and heap entries of both searches.
+
+Notes:
+
+  - If start or end is not in the hierarchy, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func (h *Hierarchy) FindPlanWithContext(
+	ctx context.Context,
+	start, end int64,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	// Input Processing
+	if _, ok := h.rank[start]; !ok {
+		return nil, gppErrors.StartNodeNotFound{ID: start}
+	}
+	if _, ok := h.rank[end]; !ok {
+		return nil, gppErrors.GoalNodeNotFound{ID: end}
+	}
+
+	// Create one search up from the start and one up from the end (along reversed arcs)
+	forward := newUpwardSearch(h.up, start)
+	backward := newUpwardSearch(h.down, end)
+
+	// The cost of the best plan found so far, and where its two halves meet
+	bestCost := math.Inf(1)
+	meet := int64(0)
+	found := false
+	stats := gppErrors.SearchStatistics{MaxHeapSize: 2}
+
+	// Algorithm
+	for {
+		// Advance the search with the cheaper frontier, unless it cannot beat the best plan
+		current, other := forward, backward
+		if current.minCost() > backward.minCost() {
+			current, other = backward, forward
+		}
+		if current.minCost() >= bestCost {
+			break
+		}
+
+		// Pop the top node off the heap
+		l := current.open.PopMin().(*label)
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = forward.open.Len() + backward.open.Len()
+		stats.FrontierCost = l.cost
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// Check if the two searches meet at the node
+		if otherCost, ok := other.distances[l.id]; ok && l.cost+otherCost < bestCost {
+			bestCost = l.cost + otherCost
+			meet, found = l.id, true
+		}
+
+		current.expand(l)
+		stats.Expansions++
+		stats.MaxHeapSize = max(stats.MaxHeapSize, forward.open.Len()+backward.open.Len())
+	}
+
+	if !found {
+		return nil, gppErrors.NoPathFound{Graph: h.graph}
+	}
+
+	// Unpack both halves of the plan into original edges
+	var ids []int64
+	var edgeCosts []float64
+	for _, ends := range append(forward.arcsTo(meet, false), backward.arcsTo(meet, true)...) {
+		h.unpack(ends[0], ends[1], &ids, &edgeCosts)
+	}
+
+	sequence := []graph.Node{h.node(start)}
+	for _, id := range ids {
+		sequence = append(sequence, h.node(id))
+	}
+
+	return &planning.Plan{
+		Sequence:  sequence,
+		EdgeCosts: edgeCosts,
+		CostToGo:  bestCost,
+	}, nil
+}
+
+/*
+unpack
+Description:
+
+	Appends the original edges that the arc from the node with ID from to
+	the node with ID to stands for: the head of each edge to ids and its
+	weight to edgeCosts.
+*/
+func (h *Hierarchy) unpack(from, to int64, ids *[]int64, edgeCosts *[]float64) {
+	// Depth-first, with the second half of each shortcut pushed first
+	stack := [][2]int64{{from, to}}
+	for len(stack) > 0 {
+		ends := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		a := h.arcs[ends]
+		if !a.Shortcut {
+			*ids = append(*ids, ends[1])
+			*edgeCosts = append(*edgeCosts, a.Weight)
+			continue
+		}
+
+		stack = append(stack, [2]int64{a.Middle, ends[1]}, [2]int64{ends[0], a.Middle})
+	}
+}
+
+// =======
+// Objects
+// =======
+
+/*
+upwardSearch
+Description:
+
+	The state of one of the two searches of a query, which follows
+	the arcs in adjacency from each node it settles.
+*/
+type upwardSearch struct {
+	adjacency map[int64][]arc
+	open      *planningHeap.IndexedPlanningHeap
+	distances map[int64]float64 // The cheapest cost found to each node
+	parents   map[int64]int64   // The node before each node on the cheapest way to it
+	closed    map[int64]bool
+}
+
+/*
+newUpwardSearch
+Description:
+
+	Creates an upwardSearch over adjacency that starts at the node with ID source.
+*/
+func newUpwardSearch(adjacency map[int64][]arc, source int64) *upwardSearch {
+	us := &upwardSearch{
+		adjacency: adjacency,
+		open:      planningHeap.NewIndexedPlanningHeap(),
+		distances: map[int64]float64{source: 0.0},
+		parents:   make(map[int64]int64),
+		closed:    make(map[int64]bool),
+	}
+	us.open.Push(&label{id: source, cost: 0.0})
+
+	return us
+}
+
+/*
+minCost
+Description:
+
+	Returns the smallest cost on the frontier (+Inf if it is empty).
+*/
+func (us *upwardSearch) minCost() float64 {
+	if us.open.Len() == 0 {
+		return math.Inf(1)
+	}
+
+	return us.open.Min().Cost()
+}
+
+/*
+expand
+Description:
+
+	Closes the node of l and relaxes the arcs that leave it.
+*/
+func (us *upwardSearch) expand(l *label) {
+	us.closed[l.id] = true
+
+	for _, a := range us.adjacency[l.id] {
+		if us.closed[a.To] {
+			continue
+		}
+
+		candidate := l.cost + a.Weight
+		if d, ok := us.distances[a.To]; ok && d <= candidate {
+			continue
+		}
+		us.distances[a.To] = candidate
+		us.parents[a.To] = l.id
+
+		if us.open.Contains(a.To) {
+			us.open.DecreaseKey(&label{id: a.To, cost: candidate})
+			continue
+		}
+		us.open.Push(&label{id: a.To, cost: candidate})
+	}
+}
+
+/*
+arcsTo
+Description:
+
+	Returns the arcs (as {from, to} pairs of the original graph) on the
+	cheapest way from the source to the node with ID id. If reversed is
+	true, the search ran along reversed arcs, so the arcs are returned
+	from id to the source instead.
+*/
+func (us *upwardSearch) arcsTo(id int64, reversed bool) [][2]int64 {
+	var arcs [][2]int64
+	for current := id; ; {
+		parent, ok := us.parents[current]
+		if !ok {
+			break
+		}
+
+		if reversed {
+			arcs = append(arcs, [2]int64{current, parent})
+		} else {
+			arcs = append([][2]int64{{parent, current}}, arcs...)
+		}
+		current = parent
+	}
+
+	return arcs
+}
@@ -0,0 +1,175 @@
+package contractionHierarchies
+
+import (
+	"cmp"
+	"encoding/gob"
+	"fmt"
+	"gonum.org/v1/gonum/graph"
+	"io"
+	"slices"
+)
+
+/*
+serialize.go
+Description:
+
+	Defines how hierarchies are saved and loaded, so that the
+	preprocessing only needs to run once per graph.
+*/
+
+// =========
+// Constants
+// =========
+
+const formatVersion = 1
+
+// =======
+// Objects
+// =======
+
+/*
+hierarchyFile
+Description:
+
+	The serialized form of a Hierarchy (encoded with encoding/gob).
+*/
+type hierarchyFile struct {
+	Version int
+	Order   []int64
+	Arcs    []fileArc
+}
+
+/*
+fileArc
+Description:
+
+	The serialized form of an arc from the node with ID From.
+*/
+type fileArc struct {
+	From     int64
+	To       int64
+	Weight   float64
+	Middle   int64
+	Shortcut bool
+}
+
+/*
+countingWriter
+Description:
+
+	Counts the bytes written to the wrapped writer.
+*/
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.count += int64(n)
+	return n, err
+}
+
+// =========
+// Functions
+// =========
+
+/*
+Read
+Description:
+
+	Loads a hierarchy written by WriteTo. The nodes of plans are taken
+	from the graph g, which should be the graph the hierarchy was built
+	from (if g is nil, plans contain simple.Node values instead).
+	Returns an UnsupportedVersionError or a CorruptHierarchyError if the
+	data cannot be used (e.g., a shortcut skips a node that does not rank
+	below both of its ends).
+*/
+func Read(r io.Reader, g graph.Graph) (*Hierarchy, error) {
+	// Input Processing
+	var file hierarchyFile
+	if err := gob.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("could not decode hierarchy: %w", err)
+	}
+
+	if file.Version != formatVersion {
+		return nil, UnsupportedVersionError{Version: file.Version}
+	}
+
+	rank := make(map[int64]int, len(file.Order))
+	for idx, id := range file.Order {
+		if _, ok := rank[id]; ok {
+			return nil, CorruptHierarchyError{Reason: fmt.Sprintf("node %v is ranked twice", id)}
+		}
+		rank[id] = idx
+	}
+	ranked := func(id int64) bool {
+		_, ok := rank[id]
+		return ok
+	}
+
+	// Algorithm
+	arcs := make(map[[2]int64]arc, len(file.Arcs))
+	for _, fa := range file.Arcs {
+		if !ranked(fa.From) || !ranked(fa.To) || (fa.Shortcut && !ranked(fa.Middle)) {
+			return nil, CorruptHierarchyError{Reason: fmt.Sprintf("arc %v -> %v uses a node without a rank", fa.From, fa.To)}
+		}
+		arcs[[2]int64{fa.From, fa.To}] = arc{To: fa.To, Weight: fa.Weight, Middle: fa.Middle, Shortcut: fa.Shortcut}
+	}
+
+	for ends, a := range arcs {
+		if !a.Shortcut {
+			continue
+		}
+
+		// The middle node was contracted before both ends, which also
+		// guarantees that unpacking the shortcut terminates
+		if rank[a.Middle] >= rank[ends[0]] || rank[a.Middle] >= rank[ends[1]] {
+			return nil, CorruptHierarchyError{Reason: fmt.Sprintf("shortcut %v -> %v skips a node that does not rank below its ends", ends[0], ends[1])}
+		}
+
+		_, first := arcs[[2]int64{ends[0], a.Middle}]
+		_, second := arcs[[2]int64{a.Middle, ends[1]}]
+		if !first || !second {
+			return nil, CorruptHierarchyError{Reason: fmt.Sprintf("shortcut %v -> %v cannot be unpacked", ends[0], ends[1])}
+		}
+	}
+
+	return newHierarchy(g, file.Order, arcs), nil
+}
+
+// =======
+// Methods
+// =======
+
+/*
+WriteTo
+Description:
+
+	Writes the hierarchy to w (without the graph it was built from),
+	so that it can be loaded again with Read. Returns the number of
+	bytes written. The output is the same every time for the same hierarchy.
+*/
+func (h *Hierarchy) WriteTo(w io.Writer) (int64, error) {
+	// Setup
+	file := hierarchyFile{
+		Version: formatVersion,
+		Order:   h.order,
+		Arcs:    make([]fileArc, 0, len(h.arcs)),
+	}
+
+	for ends, a := range h.arcs {
+		file.Arcs = append(file.Arcs, fileArc{From: ends[0], To: ends[1], Weight: a.Weight, Middle: a.Middle, Shortcut: a.Shortcut})
+	}
+	slices.SortFunc(file.Arcs, func(a, b fileArc) int {
+		if a.From != b.From {
+			return cmp.Compare(a.From, b.From)
+		}
+		return cmp.Compare(a.To, b.To)
+	})
+
+	// Algorithm
+	cw := &countingWriter{w: w}
+	err := gob.NewEncoder(cw).Encode(file)
+	return cw.count, err
+}
@@ -0,0 +1,394 @@
+package dStarLite
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/internal/incremental"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"reflect"
+)
+
+/*
+planner.go
+Description:
+
+	Defines an incremental planner based on the D* Lite algorithm.
+	D* Lite searches backward from the goal and keeps its search state
+	between calls, so when edge costs change or the start moves (e.g.,
+	because a robot discovered a blocked edge while driving), only the
+	affected part of the search is repaired instead of planning from scratch.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Heuristic
+Description:
+
+	Estimates the cost of moving from the node from to the node to.
+	D* Lite uses it to estimate the cost from the start to each node,
+	so it must never overestimate and must satisfy the triangle inequality.
+*/
+type Heuristic func(from, to graph.Node) float64
+
+/*
+Planner
+Description:
+
+	A planning.Planner that uses the D* Lite algorithm.
+	If Heuristic is nil, then a heuristic of zero is used.
+	Each call to Plan is limited by Budget (the zero value means no limits).
+
+	The planner is bound to the graph of its first call to Plan (or the
+	graph given to Reset). Later calls must pass the same graph (compared
+	with ==, so graphs must have comparable types, such as pointers), or
+	they return a gppErrors.UnsupportedGraph error; call Reset before
+	planning in another graph. As long as the goal stays the same, the previous search is
+	reused: a new start node is handled by D* Lite's key modifier, and
+	changes to the graph must be reported with UpdateEdge before the next
+	call. Calling Plan with a different goal starts a new search.
+
+	The zero value is ready to use. A Planner must not be copied after
+	its first call to Plan.
+*/
+type Planner struct {
+	Heuristic Heuristic
+	Budget    planning.Budget
+
+	graph    graph.Weighted
+	reversed graph.Weighted // The graph with its edges reversed (used to find predecessors)
+	start    int64
+	goal     int64
+	km       float64 // The key modifier (the total heuristic distance the start has moved)
+	state    *incremental.State
+}
+
+var _ planning.Planner = &Planner{}
+
+// =========
+// Functions
+// =========
+
+/*
+NewPlanner
+Description:
+
+	Creates a D* Lite planner that uses the given heuristic.
+*/
+func NewPlanner(heuristic Heuristic) *Planner {
+	return &Planner{Heuristic: heuristic}
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Plan
+Description:
+
+	Generates a plan from node start to node goal using the D* Lite
+	algorithm, reusing the state of the previous call if goal has not
+	changed. Binds the planner to g if it is not bound yet, and returns
+	a gppErrors.UnsupportedGraph error if it is bound to another graph
+	(or if g cannot be compared with ==).
+
+Notes:
+
+  - If the search is stopped by the context or the budget, the state is
+    kept, so a later call continues the search where it stopped.
+*/
+func (p *Planner) Plan(
+	ctx context.Context,
+	g graph.Weighted,
+	start, goal int64,
+) (*planning.Plan, error) {
+	// Input Processing
+	if p.graph == nil {
+		p.Reset(g)
+	}
+
+	if !reflect.ValueOf(g).Comparable() {
+		return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "D* Lite needs a graph that can be compared with ==, such as a pointer"}
+	}
+	if g != p.graph {
+		return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "the planner is bound to another graph; call Reset"}
+	}
+
+	if err := planning.CheckEndpoints(p.graph, start, goal); err != nil {
+		return nil, err
+	}
+
+	// Start over if the search was reset or the goal changed (or the old start
+	// is gone, so the key modifier cannot be computed); otherwise, move the start
+	if p.state == nil || p.goal != goal || !planning.HasNode(p.graph, p.start) {
+		p.reset(start, goal)
+	} else if start != p.start {
+		p.km += p.heuristic(p.start, start)
+		p.start = start
+	}
+
+	// Algorithm
+	if err := p.computeShortestPath(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.extractPlan()
+}
+
+/*
+UpdateEdge
+Description:
+
+	Tells the planner that the edge from the node with ID from to the
+	node with ID to was added, removed or changed its weight in the
+	graph. Call it after changing the graph and before the next call
+	to Plan. On undirected graphs, both directions are updated.
+
+Notes:
+
+  - When a node is removed from the graph, report each of its edges.
+  - Does nothing if Plan has not been called yet.
+*/
+func (p *Planner) UpdateEdge(from, to int64) {
+	// Input Processing
+	if p.state == nil {
+		return
+	}
+
+	// Algorithm
+	p.updateOrForget(from)
+	if _, directed := p.graph.(graph.Directed); !directed {
+		p.updateOrForget(to)
+	}
+}
+
+/*
+Reset
+Description:
+
+	Discards the search state and binds the planner to the graph g, so
+	the next call to Plan starts from scratch in g. With a nil g, the
+	next call to Plan binds the planner to its own graph.
+*/
+func (p *Planner) Reset(g graph.Weighted) {
+	p.graph = g
+	p.reversed = nil
+	p.state = nil
+}
+
+/*
+reset
+Description:
+
+	Initializes a new search from start to goal through the planner's graph.
+*/
+func (p *Planner) reset(start, goal int64) {
+	p.reversed = planning.Reverse(p.graph)
+	p.start = start
+	p.goal = goal
+	p.km = 0.0
+	p.state = incremental.NewState(goal)
+	p.state.Open.Push(p.calculateKey(goal))
+}
+
+/*
+computeShortestPath
+Description:
+
+	Expands inconsistent nodes until the start is consistent and no
+	node in the open list can improve the cost from the start.
+*/
+func (p *Planner) computeShortestPath(ctx context.Context) error {
+	// Setup
+	stats := gppErrors.SearchStatistics{MaxHeapSize: p.state.Open.Len()}
+
+	// Algorithm
+	for p.state.Open.Len() > 0 {
+		top := p.state.Open.Min().(*incremental.Key)
+		startKey := p.calculateKey(p.start)
+		if !top.Less(startKey) && p.state.Consistent(p.start) {
+			break
+		}
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = p.state.Open.Len()
+		stats.FrontierCost = top.K1
+		if err := p.Budget.Check(ctx, stats); err != nil {
+			return err
+		}
+
+		// The key is out of date because the start moved; reinsert it
+		u := top.ID
+		newKey := p.calculateKey(u)
+		if top.Less(newKey) {
+			p.state.Open.Update(newKey)
+			continue
+		}
+
+		p.state.Open.Remove(u)
+		stats.Expansions++
+
+		if p.state.G(u) > p.state.RHS(u) {
+			// Overconsistent: lower g and update the predecessors
+			p.state.SetG(u, p.state.RHS(u))
+			p.updatePredecessors(u)
+		} else {
+			// Underconsistent: raise g and update the node and its predecessors
+			p.state.SetG(u, math.Inf(1))
+			p.updateVertex(u)
+			p.updatePredecessors(u)
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, p.state.Open.Len())
+	}
+
+	return nil
+}
+
+/*
+extractPlan
+Description:
+
+	Follows the cheapest successors from the start to the goal.
+	Returns a gppErrors.NoPathFound error if the goal cannot be reached.
+*/
+func (p *Planner) extractPlan() (*planning.Plan, error) {
+	// Input Processing
+	if math.IsInf(p.state.G(p.start), 1) {
+		return nil, gppErrors.NoPathFound{Graph: p.graph}
+	}
+
+	// Algorithm
+	plan := &planning.Plan{
+		Sequence: []graph.Node{p.graph.Node(p.start)},
+	}
+	visited := map[int64]bool{p.start: true}
+
+	current := p.start
+	for current != p.goal {
+		// Find the successor with the cheapest cost to the goal
+		next, nextEdgeCost := int64(0), math.Inf(1)
+		best := math.Inf(1)
+		successors := p.graph.From(current)
+		for successors.Next() {
+			s := successors.Node().ID()
+			c := p.cost(current, s)
+			if c+p.state.G(s) < best {
+				next, nextEdgeCost, best = s, c, c+p.state.G(s)
+			}
+		}
+
+		if math.IsInf(best, 1) || visited[next] {
+			return nil, gppErrors.NoPathFound{Graph: p.graph}
+		}
+
+		visited[next] = true
+		plan.Sequence = append(plan.Sequence, p.graph.Node(next))
+		plan.EdgeCosts = append(plan.EdgeCosts, nextEdgeCost)
+		plan.CostToGo += nextEdgeCost
+		current = next
+	}
+
+	return plan, nil
+}
+
+/*
+updateVertex
+Description:
+
+	Recomputes the rhs value of the node u from its successors and
+	puts u in the open list if (and only if) it is inconsistent.
+*/
+func (p *Planner) updateVertex(u int64) {
+	// Recompute rhs
+	if u != p.goal {
+		rhs := math.Inf(1)
+		successors := p.graph.From(u)
+		for successors.Next() {
+			s := successors.Node().ID()
+			rhs = min(rhs, p.cost(u, s)+p.state.G(s))
+		}
+		p.state.SetRHS(u, rhs)
+	}
+
+	// Update the open list
+	if p.state.Consistent(u) {
+		p.state.Open.Remove(u)
+	} else {
+		p.state.Open.Update(p.calculateKey(u))
+	}
+}
+
+/*
+updatePredecessors
+Description:
+
+	Calls updateVertex on every node with an edge to u.
+*/
+func (p *Planner) updatePredecessors(u int64) {
+	predecessors := p.reversed.From(u)
+	for predecessors.Next() {
+		p.updateVertex(predecessors.Node().ID())
+	}
+}
+
+/*
+updateOrForget
+Description:
+
+	Updates the node with ID id after one of its edges changed,
+	or drops its state if it is no longer in the graph.
+*/
+func (p *Planner) updateOrForget(id int64) {
+	if planning.HasNode(p.graph, id) {
+		p.updateVertex(id)
+		return
+	}
+
+	p.state.Forget(id)
+}
+
+/*
+calculateKey
+Description:
+
+	Returns the current key of the node with ID id.
+*/
+func (p *Planner) calculateKey(id int64) *incremental.Key {
+	return p.state.Key(id, p.heuristic(p.start, id)+p.km)
+}
+
+/*
+heuristic
+Description:
+
+	Returns the heuristic estimate of the cost from the node with ID from
+	to the node with ID to (zero if the planner has no Heuristic).
+*/
+func (p *Planner) heuristic(from, to int64) float64 {
+	if p.Heuristic == nil {
+		return 0.0
+	}
+
+	return p.Heuristic(p.graph.Node(from), p.graph.Node(to))
+}
+
+/*
+cost
+Description:
+
+	Returns the weight of the edge from the node with ID from to the
+	node with ID to, or +Inf if there is no such edge.
+*/
+func (p *Planner) cost(from, to int64) float64 {
+	w, ok := p.graph.Weight(from, to)
+	if !ok {
+		return math.Inf(1)
+	}
+
+	return w
+}
@@ -1,8 +1,9 @@
 package djikstra
 
 import (
-	"container/heap"
+	"context"
 	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
 	"github.com/GraphPathPlanning.go/planningHeap"
 	"gonum.org/v1/gonum/graph"
 	"slices"
@@ -19,10 +20,14 @@ Description:
 // Type Definitions
 // ================
 
-type Plan struct {
-	Sequence []graph.Node // The sequence of nodes in the path (start @ 0, and end @ len(Sequence) - 1
-	CostToGo float64
-}
+/*
+Plan
+Description:
+
+	The plans returned by this package (an alias of planning.Plan,
+	kept so that code written against the old Plan type still compiles).
+*/
+type Plan = planning.Plan
 
 // =======
 // Functions
@@ -32,15 +37,47 @@ type Plan struct {
 FindPlan
 Description:
 
-	Generates a plan using the A* algorithm.
+	Generates a plan using the Djikstra's algorithm.
 	To move from node start to node end through the graph
 	g.
+
+Notes:
+
+  - Each graph node is expanded at most once.
 */
 func FindPlan(
-	g graph.WeightedUndirected,
+	g graph.Weighted,
+	start, end int64,
+) (*planning.Plan, error) {
+	return FindPlanWithContext(context.Background(), g, start, end, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates a plan using the Djikstra's algorithm, like FindPlan,
+	but stops early if the context ctx is done or if the search
+	exceeds one of the limits in budget.
+
+Notes:
+
+  - When the search is stopped early, the error is a
+    gppErrors.SearchCancelled or a gppErrors.SearchBudgetExceeded
+    containing the statistics of the partial search.
+  - If start or end is not in the graph, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindPlanWithContext(
+	ctx context.Context,
+	g graph.Weighted,
 	start, end int64,
-) (*Plan, error) {
-	// Constants
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
 
 	// Create initial planning node and heap
 	pn0 := &PlanningNode{
@@ -50,27 +87,53 @@ func FindPlan(
 		CostToGo:         0.0,
 	}
 
-	var heap0 planningHeap.PlanningHeap
-	heap.Init(&heap0)
-	heap.Push(&heap0, pn0)
+	open := planningHeap.NewIndexedPlanningHeap()
+	open.Push(pn0)
+
+	// Track the nodes that have already been expanded (i.e., the closed set).
+	// The open heap holds at most one (the cheapest) planning node per graph node.
+	closed := make(map[int64]bool)
+	stats := gppErrors.SearchStatistics{MaxHeapSize: open.Len()}
 
 	// Algorithm
-	for len(heap0) > 0 {
+	for open.Len() > 0 {
 		// Pop the top node off the heap
-		pn := heap.Pop(&heap0).(*PlanningNode)
+		pn := open.PopMin().(*PlanningNode)
+		currentID := pn.NodeID()
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = open.Len()
+		stats.FrontierCost = pn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
 
 		// If we have reached the end, return the plan
-		if pn.CurrentGraphNode.ID() == end {
+		if currentID == end {
 			return UnrollPlanFrom(pn), nil
 		}
 
-		// Otherwise, expand the node
+		// Otherwise, close and expand the node
+		closed[currentID] = true
 		expandedNodes := pn.Expand()
+		stats.Expansions++
 
-		// Add the expanded nodes to the heap
+		// Add the expanded nodes to the heap, or lower the cost of
+		// their existing entries if we found a cheaper route
 		for _, newPN := range expandedNodes {
-			heap.Push(&heap0, newPN)
+			newID := newPN.NodeID()
+			if closed[newID] {
+				continue
+			}
+
+			if !open.Contains(newID) {
+				open.Push(newPN)
+				continue
+			}
+
+			open.DecreaseKey(newPN)
 		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, open.Len())
 
 	}
 
@@ -86,7 +149,7 @@ Description:
 	Hopefully, you try this only on a planning node
 	that reaches the end.
 */
-func UnrollPlanFrom(pn *PlanningNode) *Plan {
+func UnrollPlanFrom(pn *PlanningNode) *planning.Plan {
 	// Check to see if plan is empty
 	if pn == nil {
 		return nil
@@ -95,6 +158,7 @@ func UnrollPlanFrom(pn *PlanningNode) *Plan {
 	// Iterate through each of the nodes in the plan
 	current := pn
 	var reversedPlan []graph.Node
+	var reversedEdgeCosts []float64
 	for current != nil {
 		// Add current node (in graph) to plan
 		reversedPlan = append(
@@ -102,6 +166,14 @@ func UnrollPlanFrom(pn *PlanningNode) *Plan {
 			current.Graph.Node(current.CurrentGraphNode.ID()),
 		)
 
+		// Add the cost of the edge that led to the current node
+		if current.PreviousInPlan != nil {
+			reversedEdgeCosts = append(
+				reversedEdgeCosts,
+				current.CostToGo-current.PreviousInPlan.CostToGo,
+			)
+		}
+
 		// Update current
 		current = current.PreviousInPlan
 	}
@@ -110,8 +182,12 @@ func UnrollPlanFrom(pn *PlanningNode) *Plan {
 	forwardPlan := reversedPlan
 	slices.Reverse(forwardPlan)
 
-	return &Plan{
-		Sequence: forwardPlan,
-		CostToGo: pn.CostToGo,
+	forwardEdgeCosts := reversedEdgeCosts
+	slices.Reverse(forwardEdgeCosts)
+
+	return &planning.Plan{
+		Sequence:  forwardPlan,
+		EdgeCosts: forwardEdgeCosts,
+		CostToGo:  pn.CostToGo,
 	}
 }
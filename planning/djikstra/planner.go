@@ -0,0 +1,51 @@
+package djikstra
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planner.go
+Description:
+
+	Adapts Djikstra's algorithm to the planning.Planner interface.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Planner
+Description:
+
+	A planning.Planner that uses Djikstra's algorithm.
+	The search is limited by Budget (the zero value means no limits).
+*/
+type Planner struct {
+	Budget planning.Budget
+}
+
+var _ planning.Planner = Planner{}
+
+// =======
+// Methods
+// =======
+
+/*
+Plan
+Description:
+
+	Generates a plan from node start to node goal through the graph g
+	using Djikstra's algorithm.
+*/
+func (p Planner) Plan(
+	ctx context.Context,
+	g graph.Weighted,
+	start, goal int64,
+) (*planning.Plan, error) {
+	// Algorithm
+	return FindPlanWithContext(ctx, g, start, goal, p.Budget)
+}
@@ -22,7 +22,7 @@ Description:
 	An implementation of a PlanningNode for use in the A* algorithm.
 */
 type PlanningNode struct {
-	Graph            graph.WeightedUndirected
+	Graph            graph.Weighted
 	CurrentGraphNode graph.Node
 	PreviousInPlan   *PlanningNode
 	CostToGo         float64 // The cost to go from the current node to the goal node
@@ -43,6 +43,16 @@ func (pn *PlanningNode) Cost() float64 {
 	return pn.CostToGo
 }
 
+/*
+NodeID
+Description:
+
+	Returns the ID of the graph node that this planning node represents.
+*/
+func (pn *PlanningNode) NodeID() int64 {
+	return pn.CurrentGraphNode.ID()
+}
+
 /*
 CalculateCostToGo
 Description:
@@ -65,7 +75,7 @@ func (pn *PlanningNode) CalculateCostToGo() float64 {
 
 	// Otherwise, we should return the cost of the previous node
 	// plus the cost of the edge between the previous node and the current node.
-	lastEdge := underlyingGraph.WeightedEdgeBetween(
+	lastEdge := underlyingGraph.WeightedEdge(
 		pn.PreviousInPlan.CurrentGraphNode.ID(),
 		pn.CurrentGraphNode.ID(),
 	)
@@ -77,7 +87,8 @@ func (pn *PlanningNode) CalculateCostToGo() float64 {
 Expand
 Description:
 
-	"Expands" from the current graph node to all of the adjacent nodes.
+	"Expands" from the current graph node to all of the adjacent nodes
+	(i.e., its successors, if the graph is directed).
 	Returns a slice of PlanningNodes that represent the expanded nodes.
 */
 func (pn *PlanningNode) Expand() []*PlanningNode {
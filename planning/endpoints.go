@@ -0,0 +1,50 @@
+package planning
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+endpoints.go
+Description:
+
+	Defines the checks that planners run on the start and goal
+	nodes before searching.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+CheckEndpoints
+Description:
+
+	Returns a gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound
+	error if the start or goal node is not in the graph g, and nil otherwise.
+*/
+func CheckEndpoints(g graph.Graph, start, goal int64) error {
+	// Algorithm
+	if !HasNode(g, start) {
+		return gppErrors.StartNodeNotFound{ID: start}
+	}
+
+	if !HasNode(g, goal) {
+		return gppErrors.GoalNodeNotFound{ID: goal}
+	}
+
+	return nil
+}
+
+/*
+HasNode
+Description:
+
+	Returns true if the graph g contains the node with the given ID
+	(i.e., if g.Node returns a node, as graph.Graph requires).
+*/
+func HasNode(g graph.Graph, id int64) bool {
+	// Algorithm
+	return g.Node(id) != nil
+}
@@ -0,0 +1,164 @@
+package heuristics
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"slices"
+)
+
+/*
+distances.go
+Description:
+
+	Defines the distances between two positions that the heuristics
+	of this package estimate costs with. Positions can have any number
+	of dimensions, but both must have the same number: the distances
+	panic with mat.ErrShape otherwise (the heuristics of this package
+	give an estimate of 0 instead).
+*/
+
+// =========
+// Constants
+// =========
+
+/*
+EarthRadius
+Description:
+
+	The mean radius of the Earth, in meters.
+*/
+const EarthRadius = 6371008.8
+
+// =========
+// Functions
+// =========
+
+/*
+EuclideanDistance
+Description:
+
+	Returns the length of the straight line between the positions a and b.
+*/
+func EuclideanDistance(a, b mat.Vector) float64 {
+	sum := 0.0
+	for _, d := range differences(a, b) {
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+/*
+ManhattanDistance
+Description:
+
+	Returns the sum of the differences between the coordinates of the
+	positions a and b (the length of a path that only moves along axes).
+*/
+func ManhattanDistance(a, b mat.Vector) float64 {
+	sum := 0.0
+	for _, d := range differences(a, b) {
+		sum += d
+	}
+
+	return sum
+}
+
+/*
+ChebyshevDistance
+Description:
+
+	Returns the largest difference between the coordinates of the positions
+	a and b (the length of a path where diagonal moves cost as much as
+	moves along axes).
+*/
+func ChebyshevDistance(a, b mat.Vector) float64 {
+	largest := 0.0
+	for _, d := range differences(a, b) {
+		largest = max(largest, d)
+	}
+
+	return largest
+}
+
+/*
+OctileDistance
+Description:
+
+	Returns the length of the shortest path from position a to position b
+	on a grid where moves may change any number of coordinates by one and
+	cost the Euclidean length of the move (e.g., sqrt(2) for a diagonal
+	move in 2D and sqrt(3) in 3D).
+*/
+func OctileDistance(a, b mat.Vector) float64 {
+	// Setup
+	diffs := differences(a, b)
+	slices.Sort(diffs)
+	slices.Reverse(diffs)
+
+	// Algorithm
+	// The k-th largest difference is covered by moves along k axes at once,
+	// each of which costs sqrt(k) - sqrt(k - 1) more than a move along k - 1 axes
+	sum := 0.0
+	for idx, d := range diffs {
+		k := float64(idx + 1)
+		sum += (math.Sqrt(k) - math.Sqrt(k-1)) * d
+	}
+
+	return sum
+}
+
+/*
+GreatCircleDistance
+Description:
+
+	Returns the length of the shortest path between the positions a and b
+	over the surface of a sphere with the given radius (with the haversine
+	formula). The first coordinate of a position is its latitude and the
+	second is its longitude, both in degrees; other coordinates are ignored.
+	Panics with mat.ErrShape if a position has fewer than two coordinates.
+*/
+func GreatCircleDistance(a, b mat.Vector, radius float64) float64 {
+	// Setup
+	if a.Len() < 2 || b.Len() < 2 {
+		panic(mat.ErrShape)
+	}
+
+	lat1, lat2 := radians(a.AtVec(0)), radians(b.AtVec(0))
+	dLat := lat2 - lat1
+	dLon := radians(b.AtVec(1) - a.AtVec(1))
+
+	// Algorithm
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * radius * math.Asin(math.Sqrt(min(1.0, h)))
+}
+
+/*
+differences
+Description:
+
+	Returns the absolute differences between the coordinates of the
+	positions a and b. Panics with mat.ErrShape if their lengths differ.
+*/
+func differences(a, b mat.Vector) []float64 {
+	if a.Len() != b.Len() {
+		panic(mat.ErrShape)
+	}
+
+	diffs := make([]float64, a.Len())
+	for idx := range diffs {
+		diffs[idx] = math.Abs(a.AtVec(idx) - b.AtVec(idx))
+	}
+
+	return diffs
+}
+
+/*
+radians
+Description:
+
+	Converts an angle from degrees to radians.
+*/
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180.0
+}
@@ -0,0 +1,55 @@
+package heuristics
+
+import "fmt"
+
+/*
+errors.go
+Description:
+
+	Defines the errors returned when a heuristic fails validation.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+InadmissibleError
+Description:
+
+	Returned when a heuristic estimates that reaching the goal from the
+	node with ID Node costs Estimate, but a plan that costs only Cost exists.
+*/
+type InadmissibleError struct {
+	Node     int64
+	Estimate float64
+	Cost     float64
+}
+
+func (e InadmissibleError) Error() string {
+	return fmt.Sprintf(
+		"heuristic is not admissible: it estimates %v from node %v, but the goal can be reached for %v",
+		e.Estimate, e.Node, e.Cost,
+	)
+}
+
+/*
+InconsistentError
+Description:
+
+	Returned when the estimate of a heuristic drops by more than the
+	weight of the edge from the node with ID From to the node with ID To.
+*/
+type InconsistentError struct {
+	From, To     int64
+	Weight       float64
+	FromEstimate float64
+	ToEstimate   float64
+}
+
+func (e InconsistentError) Error() string {
+	return fmt.Sprintf(
+		"heuristic is not consistent: it estimates %v from node %v, but %v from node %v after an edge that costs %v",
+		e.FromEstimate, e.From, e.ToEstimate, e.To, e.Weight,
+	)
+}
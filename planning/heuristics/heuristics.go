@@ -0,0 +1,198 @@
+package heuristics
+
+import (
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+	"math"
+)
+
+/*
+heuristics.go
+Description:
+
+	Defines ready-made heuristics for the A* planners on graphs whose
+	nodes are position_graph.Node values (e.g., a PositionGraph or a
+	DirectedPositionGraph). Each heuristic estimates the cost to one goal
+	with a distance between the positions of the current node and the goal.
+
+	A heuristic is admissible if every edge costs at least the distance
+	between the positions of its nodes (e.g., the default weights of a
+	PositionGraph for Euclidean), and then it is also consistent.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+FromDistance
+Description:
+
+	Returns a heuristic that estimates the cost from a node to the node
+	with ID goal as distance(position of the node, position of the goal).
+	Nodes that are not position_graph.Node values have no position
+	and get an estimate of 0, and so do nodes whose position has a
+	different number of coordinates than the goal's.
+*/
+func FromDistance(goal int64, distance func(a, b mat.Vector) float64) func(*aStar.PlanningNode) float64 {
+	return func(pn *aStar.PlanningNode) float64 {
+		from, ok := Position(pn.CurrentGraphNode)
+		if !ok {
+			return 0.0
+		}
+
+		to, ok := Position(pn.Graph.Node(goal))
+		if !ok || to.Len() != from.Len() {
+			return 0.0
+		}
+
+		return distance(from, to)
+	}
+}
+
+/*
+ToNearestGoal
+Description:
+
+	Returns a goal-aware heuristic (see aStar.Heuristic) that estimates the
+	cost from a node as the distance from its position to the position of
+	the nearest goal, so that one heuristic serves every query, e.g.:
+
+		planner := aStar.Planner{GoalHeuristic: heuristics.ToNearestGoal(heuristics.EuclideanDistance)}
+
+	Nodes without a position, and goals without one (or with a different
+	number of coordinates than the node's), give an estimate of 0.
+*/
+func ToNearestGoal(distance func(a, b mat.Vector) float64) aStar.Heuristic {
+	return aStar.HeuristicFunc(func(g graph.Weighted, current graph.Node, goals []int64) float64 {
+		from, ok := Position(current)
+		if !ok {
+			return 0.0
+		}
+
+		nearest := math.Inf(1)
+		for _, goal := range goals {
+			to, ok := Position(g.Node(goal))
+			if !ok || to.Len() != from.Len() {
+				return 0.0
+			}
+			nearest = min(nearest, distance(from, to))
+		}
+
+		if math.IsInf(nearest, 1) {
+			return 0.0
+		}
+		return nearest
+	})
+}
+
+/*
+Euclidean
+Description:
+
+	Returns a heuristic that estimates the cost to the node with ID goal
+	as the straight-line distance (see EuclideanDistance).
+*/
+func Euclidean(goal int64) func(*aStar.PlanningNode) float64 {
+	return FromDistance(goal, EuclideanDistance)
+}
+
+/*
+Manhattan
+Description:
+
+	Returns a heuristic that estimates the cost to the node with ID goal
+	with ManhattanDistance (admissible on 4-connected 2D grids and
+	6-connected 3D grids with unit edge costs).
+*/
+func Manhattan(goal int64) func(*aStar.PlanningNode) float64 {
+	return FromDistance(goal, ManhattanDistance)
+}
+
+/*
+Chebyshev
+Description:
+
+	Returns a heuristic that estimates the cost to the node with ID goal
+	with ChebyshevDistance (admissible on grids where every move,
+	diagonal or not, costs 1).
+*/
+func Chebyshev(goal int64) func(*aStar.PlanningNode) float64 {
+	return FromDistance(goal, ChebyshevDistance)
+}
+
+/*
+Octile
+Description:
+
+	Returns a heuristic that estimates the cost to the node with ID goal
+	with OctileDistance (admissible on 8-connected 2D grids and
+	26-connected 3D grids where each move costs its length).
+*/
+func Octile(goal int64) func(*aStar.PlanningNode) float64 {
+	return FromDistance(goal, OctileDistance)
+}
+
+/*
+GreatCircle
+Description:
+
+	Returns a heuristic that estimates the cost to the node with ID goal
+	as the great-circle distance over a sphere with the given radius
+	(see GreatCircleDistance for the layout of the positions).
+	Positions with fewer than two coordinates give an estimate of 0.
+*/
+func GreatCircle(goal int64, radius float64) func(*aStar.PlanningNode) float64 {
+	return FromDistance(goal, func(a, b mat.Vector) float64 {
+		if a.Len() < 2 || b.Len() < 2 {
+			return 0.0
+		}
+		return GreatCircleDistance(a, b, radius)
+	})
+}
+
+/*
+Haversine
+Description:
+
+	Returns a heuristic that estimates the cost to the node with ID goal
+	as the great-circle distance over the Earth, in meters, between
+	positions given as (latitude, longitude) in degrees.
+*/
+func Haversine(goal int64) func(*aStar.PlanningNode) float64 {
+	return GreatCircle(goal, EarthRadius)
+}
+
+/*
+Scaled
+Description:
+
+	Returns the heuristic h multiplied by factor. A factor below 1 converts
+	distances into other costs (e.g., 1 / the top speed turns meters into
+	travel times), and a factor above 1 gives the faster, but suboptimal,
+	weighted A* (whose plans cost at most factor times the optimal cost
+	if h is admissible).
+*/
+func Scaled(h func(*aStar.PlanningNode) float64, factor float64) func(*aStar.PlanningNode) float64 {
+	return func(pn *aStar.PlanningNode) float64 {
+		return factor * h(pn)
+	}
+}
+
+/*
+Position
+Description:
+
+	Returns the position of the node n, and false if n is not a
+	position_graph.Node (or has no position).
+*/
+func Position(n graph.Node) (mat.Vector, bool) {
+	node, ok := n.(*position_graph.Node)
+	if !ok || node == nil || node.Position == nil {
+		return nil, false
+	}
+
+	return node.Position, true
+}
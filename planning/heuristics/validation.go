@@ -0,0 +1,155 @@
+package heuristics
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+validation.go
+Description:
+
+	Defines checks of the two properties that make A* optimal: an admissible
+	heuristic never overestimates the cost to the goal, and a consistent
+	heuristic never drops by more than the weight of an edge (so that every
+	node only needs to be expanded once). Both checks look at every node of
+	the graph, so they are meant for tests rather than for every query.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+CheckAdmissible
+Description:
+
+	Returns an InadmissibleError if the heuristic h overestimates the cost
+	from some node of the graph g to the node with ID goal (as found by
+	Djikstra's algorithm), and nil otherwise. Nodes that cannot reach the
+	goal may have any estimate.
+
+Notes:
+
+  - If goal is not in the graph, the error is a gppErrors.GoalNodeNotFound.
+  - If an edge has a negative weight, the error is a gppErrors.UnsupportedGraph.
+*/
+func CheckAdmissible(g graph.Weighted, goal int64, h func(*aStar.PlanningNode) float64) error {
+	// Input Processing
+	if !planning.HasNode(g, goal) {
+		return gppErrors.GoalNodeNotFound{ID: goal}
+	}
+
+	tree, err := planning.ShortestPaths(context.Background(), planning.Reverse(g), goal)
+	if err != nil {
+		return err
+	}
+	costs := tree.Distances
+
+	// Algorithm
+	for _, id := range sortedIDs(costs) {
+		estimate := evaluate(g, id, h)
+		if exceeds(estimate, costs[id]) {
+			return InadmissibleError{Node: id, Estimate: estimate, Cost: costs[id]}
+		}
+	}
+
+	return nil
+}
+
+/*
+CheckConsistent
+Description:
+
+	Returns an InconsistentError if the estimate of the heuristic h for
+	reaching the node with ID goal drops by more than the weight of some
+	edge of the graph g, an InadmissibleError if the estimate at the goal
+	itself is not 0, and nil otherwise. A consistent heuristic is also
+	admissible.
+
+Notes:
+
+  - If goal is not in the graph, the error is a gppErrors.GoalNodeNotFound.
+*/
+func CheckConsistent(g graph.Weighted, goal int64, h func(*aStar.PlanningNode) float64) error {
+	// Input Processing
+	if !planning.HasNode(g, goal) {
+		return gppErrors.GoalNodeNotFound{ID: goal}
+	}
+
+	if estimate := evaluate(g, goal, h); exceeds(estimate, 0.0) {
+		return InadmissibleError{Node: goal, Estimate: estimate, Cost: 0.0}
+	}
+
+	// Algorithm
+	var ids []int64
+	nodes := g.Nodes()
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	slices.Sort(ids)
+
+	estimates := make(map[int64]float64, len(ids))
+	for _, id := range ids {
+		estimates[id] = evaluate(g, id, h)
+	}
+
+	for _, from := range ids {
+		var neighbors []int64
+		successors := g.From(from)
+		for successors.Next() {
+			neighbors = append(neighbors, successors.Node().ID())
+		}
+		slices.Sort(neighbors)
+
+		for _, to := range neighbors {
+			w, ok := g.Weight(from, to)
+			if ok && exceeds(estimates[from], w+estimates[to]) {
+				return InconsistentError{From: from, To: to, Weight: w, FromEstimate: estimates[from], ToEstimate: estimates[to]}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+evaluate
+Description:
+
+	Returns the estimate of the heuristic h at the node with ID id of the graph g.
+*/
+func evaluate(g graph.Weighted, id int64, h func(*aStar.PlanningNode) float64) float64 {
+	return h(&aStar.PlanningNode{Graph: g, CurrentGraphNode: g.Node(id)})
+}
+
+/*
+exceeds
+Description:
+
+	Returns true if estimate is larger than bound (beyond rounding errors).
+*/
+func exceeds(estimate, bound float64) bool {
+	return estimate > bound+1e-9*max(1.0, math.Abs(bound))
+}
+
+/*
+sortedIDs
+Description:
+
+	Returns the keys of costs in increasing order.
+*/
+func sortedIDs(costs map[int64]float64) []int64 {
+	ids := make([]int64, 0, len(costs))
+	for id := range costs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
@@ -0,0 +1,78 @@
+package incremental
+
+import "github.com/GraphPathPlanning.go/planningHeap"
+
+/*
+key.go
+Description:
+
+	Defines the two-part priority that LPA* and D* Lite use to order
+	their open lists.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+Key
+Description:
+
+	The priority of a graph node in the open list.
+	Keys are compared lexicographically: first by K1, then by K2.
+*/
+type Key struct {
+	ID int64
+	K1 float64 // min(g, rhs) + the heuristic of the planner
+	K2 float64 // min(g, rhs)
+}
+
+var _ planningHeap.TieBreakingPlanningNode = &Key{}
+
+// =======
+// Methods
+// =======
+
+/*
+Cost
+Description:
+
+	Returns the first part of the key.
+*/
+func (k *Key) Cost() float64 {
+	return k.K1
+}
+
+/*
+TieBreakCost
+Description:
+
+	Returns the second part of the key.
+*/
+func (k *Key) TieBreakCost() float64 {
+	return k.K2
+}
+
+/*
+NodeID
+Description:
+
+	Returns the ID of the graph node that this key belongs to.
+*/
+func (k *Key) NodeID() int64 {
+	return k.ID
+}
+
+/*
+Less
+Description:
+
+	Returns true iff k comes before other in the open list.
+*/
+func (k *Key) Less(other *Key) bool {
+	if k.K1 != other.K1 {
+		return k.K1 < other.K1
+	}
+
+	return k.K2 < other.K2
+}
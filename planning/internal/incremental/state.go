@@ -0,0 +1,145 @@
+package incremental
+
+import (
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"math"
+)
+
+/*
+state.go
+Description:
+
+	Defines the search state that LPA* and D* Lite keep between queries:
+	the g value (the cost found by the last expansion) and the rhs value
+	(the one-step lookahead cost) of every node, and the open list of the
+	nodes whose g and rhs values differ.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+State
+Description:
+
+	The g and rhs values of the nodes of an incremental search, and
+	its open list. Nodes without a value have a value of +Inf.
+*/
+type State struct {
+	Open *planningHeap.IndexedPlanningHeap
+
+	g   map[int64]float64
+	rhs map[int64]float64
+}
+
+// =========
+// Functions
+// =========
+
+/*
+NewState
+Description:
+
+	Creates an empty search state whose only value is an rhs value of
+	0 for the node with ID source (the node that the search grows from).
+	The caller must push the key of source onto the open list.
+*/
+func NewState(source int64) *State {
+	return &State{
+		Open: planningHeap.NewIndexedPlanningHeap(),
+		g:    make(map[int64]float64),
+		rhs:  map[int64]float64{source: 0.0},
+	}
+}
+
+// =======
+// Methods
+// =======
+
+/*
+G
+Description:
+
+	Returns the g value of the node with ID id (+Inf if it has none).
+*/
+func (s *State) G(id int64) float64 {
+	if value, ok := s.g[id]; ok {
+		return value
+	}
+
+	return math.Inf(1)
+}
+
+/*
+RHS
+Description:
+
+	Returns the rhs value of the node with ID id (+Inf if it has none).
+*/
+func (s *State) RHS(id int64) float64 {
+	if value, ok := s.rhs[id]; ok {
+		return value
+	}
+
+	return math.Inf(1)
+}
+
+/*
+SetG
+Description:
+
+	Sets the g value of the node with ID id.
+*/
+func (s *State) SetG(id int64, value float64) {
+	s.g[id] = value
+}
+
+/*
+SetRHS
+Description:
+
+	Sets the rhs value of the node with ID id.
+*/
+func (s *State) SetRHS(id int64, value float64) {
+	s.rhs[id] = value
+}
+
+/*
+Key
+Description:
+
+	Returns the key of the node with ID id, where h is the
+	heuristic term that the planner adds to min(g, rhs).
+*/
+func (s *State) Key(id int64, h float64) *Key {
+	k2 := min(s.G(id), s.RHS(id))
+	return &Key{
+		ID: id,
+		K1: k2 + h,
+		K2: k2,
+	}
+}
+
+/*
+Consistent
+Description:
+
+	Returns true if the g and rhs values of the node with ID id are
+	equal (only inconsistent nodes belong in the open list).
+*/
+func (s *State) Consistent(id int64) bool {
+	return s.G(id) == s.RHS(id)
+}
+
+/*
+Forget
+Description:
+
+	Drops the values of the node with ID id and removes it from the open list.
+*/
+func (s *State) Forget(id int64) {
+	delete(s.g, id)
+	delete(s.rhs, id)
+	s.Open.Remove(id)
+}
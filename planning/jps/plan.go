@@ -0,0 +1,329 @@
+package jps
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+plan.go
+Description:
+
+	Defines how plans are generated with Jump Point Search (JPS).
+	JPS is A* on an 8-connected, uniform-cost 2D grid that skips over the
+	many symmetric paths of the grid: instead of adding every neighbor
+	to the open heap, it "jumps" in straight lines until it reaches a
+	cell where the optimal path may turn (a jump point). This expands
+	far fewer nodes than A* and still returns an optimal plan.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlan
+Description:
+
+	Generates a plan using Jump Point Search.
+	To move from node start to node end through the grid g.
+	The plan lists every cell along the way (not only the jump points),
+	so it can be used like a plan from aStar.FindPlan.
+*/
+func FindPlan(
+	g *grid_graph.Grid,
+	start, end int64,
+) (*planning.Plan, error) {
+	return FindPlanWithContext(context.Background(), g, start, end, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates a plan using Jump Point Search, like FindPlan,
+	but stops early if the context ctx is done or if the search
+	exceeds one of the limits in budget.
+
+Notes:
+
+  - Only jump points count as expansions.
+  - The grid must be 2D and 8-connected, and all of its free cells must
+    have the same traversal cost. Otherwise, the error is a gppErrors.UnsupportedGraph.
+  - If start or end is not a free cell of the grid, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindPlanWithContext(
+	ctx context.Context,
+	g *grid_graph.Grid,
+	start, end int64,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	if g.Dimensions() != 2 || g.Connectivity() != grid_graph.Connect8 {
+		return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "jump point search needs an 8-connected 2D grid"}
+	}
+
+	cost, uniform := g.UniformCost()
+	if !uniform {
+		return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "jump point search needs every free cell to have the same cost"}
+	}
+
+	// Create initial jump point and heap
+	s := &search{
+		grid:  g,
+		table: tableFor(g.Diagonal),
+		end:   g.Cell(end),
+		scale: cost * g.CellSize(),
+	}
+
+	startCell := g.Cell(start)
+	jn0 := s.newJumpNode(startCell[0], startCell[1], nil)
+
+	open := planningHeap.NewIndexedPlanningHeap()
+	open.Push(jn0)
+
+	closed := make(map[int64]bool)
+	stats := gppErrors.SearchStatistics{MaxHeapSize: open.Len()}
+
+	// Algorithm
+	for open.Len() > 0 {
+		// Pop the top jump point off the heap
+		jn := open.PopMin().(*jumpNode)
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = open.Len()
+		stats.FrontierCost = jn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// If we have reached the end, return the plan
+		if jn.id == end {
+			return s.unrollPlanFrom(jn), nil
+		}
+
+		// Otherwise, close the jump point and jump to its successors
+		closed[jn.id] = true
+		stats.Expansions++
+
+		for _, successor := range s.successors(jn) {
+			if closed[successor.id] {
+				continue
+			}
+
+			if !open.Contains(successor.id) {
+				open.Push(successor)
+				continue
+			}
+
+			open.DecreaseKey(successor)
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, open.Len())
+	}
+
+	return nil, gppErrors.NoPathFound{Graph: g}
+}
+
+// =======
+// Objects
+// =======
+
+/*
+search
+Description:
+
+	The grid and pruning rules of a single JPS search.
+*/
+type search struct {
+	grid  *grid_graph.Grid
+	table *pruningTable
+	end   []int
+	scale float64 // The weight of an orthogonal step
+}
+
+/*
+newJumpNode
+Description:
+
+	Creates the jump node for the cell {x, y} reached from previous.
+*/
+func (s *search) newJumpNode(x, y int, previous *jumpNode) *jumpNode {
+	jn := &jumpNode{
+		x:              x,
+		y:              y,
+		id:             s.grid.ID(x, y),
+		previousInPlan: previous,
+		heuristicCost:  s.scale * octile(x-s.end[0], y-s.end[1]),
+	}
+	if previous != nil {
+		jn.costToGo = previous.costToGo + s.scale*octile(x-previous.x, y-previous.y)
+	}
+
+	return jn
+}
+
+/*
+successors
+Description:
+
+	Returns the jump points that can be reached from the jump point jn.
+*/
+func (s *search) successors(jn *jumpNode) []*jumpNode {
+	// Find the direction that we arrived from
+	arrival := 8
+	if jn.previousInPlan != nil {
+		arrival = directionIndex(sign(jn.x-jn.previousInPlan.x), sign(jn.y-jn.previousInPlan.y))
+	}
+
+	// Algorithm
+	var out []*jumpNode
+	mask := s.table[s.occupancy(jn.x, jn.y)][arrival]
+	for idx, d := range directions {
+		if mask&(1<<idx) == 0 {
+			continue
+		}
+
+		if x, y, ok := s.jump(jn.x, jn.y, d[0], d[1]); ok {
+			out = append(out, s.newJumpNode(x, y, jn))
+		}
+	}
+
+	return out
+}
+
+/*
+jump
+Description:
+
+	Steps from the cell {x, y} in the direction (dx, dy) until it finds a
+	jump point (the end, or a cell with a forced neighbor).
+	Returns false if it hits an obstacle or the edge of the grid first.
+*/
+func (s *search) jump(x, y, dx, dy int) (int, int, bool) {
+	arrival := directionIndex(dx, dy)
+	natural := naturalDirections(arrival)
+
+	for {
+		// Take a step
+		if !s.grid.CanMove([]int{x, y}, []int{dx, dy}) {
+			return 0, 0, false
+		}
+		x, y = x+dx, y+dy
+
+		// Stop at the end or at a cell with a forced neighbor
+		if x == s.end[0] && y == s.end[1] {
+			return x, y, true
+		}
+
+		if s.table[s.occupancy(x, y)][arrival]&^natural != 0 {
+			return x, y, true
+		}
+
+		// Diagonal steps also stop where a straight jump finds a jump point
+		if dx != 0 && dy != 0 {
+			if _, _, ok := s.jump(x, y, dx, 0); ok {
+				return x, y, true
+			}
+			if _, _, ok := s.jump(x, y, 0, dy); ok {
+				return x, y, true
+			}
+		}
+	}
+}
+
+/*
+occupancy
+Description:
+
+	Returns the occupancy bit mask of the 3x3 block around the cell {x, y}
+	(see blockFor).
+*/
+func (s *search) occupancy(x, y int) int {
+	occupancy := 0
+	for bit := 0; bit < 9; bit++ {
+		if !s.grid.IsBlocked(x+bit%3-1, y+bit/3-1) {
+			occupancy |= 1 << bit
+		}
+	}
+
+	return occupancy
+}
+
+/*
+unrollPlanFrom
+Description:
+
+	Unrolls a plan from the jump point jn, filling in
+	the cells between consecutive jump points.
+*/
+func (s *search) unrollPlanFrom(jn *jumpNode) *planning.Plan {
+	// Collect the cells from the end back to the start
+	var reversedIDs []int64
+	for current := jn; current != nil; current = current.previousInPlan {
+		reversedIDs = append(reversedIDs, current.id)
+
+		previous := current.previousInPlan
+		if previous == nil {
+			break
+		}
+
+		dx, dy := sign(previous.x-current.x), sign(previous.y-current.y)
+		for x, y := current.x+dx, current.y+dy; x != previous.x || y != previous.y; x, y = x+dx, y+dy {
+			reversedIDs = append(reversedIDs, s.grid.ID(x, y))
+		}
+	}
+	slices.Reverse(reversedIDs)
+
+	// Algorithm
+	plan := &planning.Plan{
+		Sequence: []graph.Node{s.grid.Node(reversedIDs[0])},
+	}
+	for idx := 1; idx < len(reversedIDs); idx++ {
+		w, _ := s.grid.Weight(reversedIDs[idx-1], reversedIDs[idx])
+		plan.Sequence = append(plan.Sequence, s.grid.Node(reversedIDs[idx]))
+		plan.EdgeCosts = append(plan.EdgeCosts, w)
+		plan.CostToGo += w
+	}
+
+	return plan
+}
+
+/*
+octile
+Description:
+
+	Returns the length of the shortest 8-connected path across an
+	empty grid between two cells that are (dx, dy) apart.
+*/
+func octile(dx, dy int) float64 {
+	adx, ady := math.Abs(float64(dx)), math.Abs(float64(dy))
+	return math.Max(adx, ady) - math.Min(adx, ady) + math.Sqrt2*math.Min(adx, ady)
+}
+
+/*
+sign
+Description:
+
+	Returns -1, 0 or 1 depending on the sign of v.
+*/
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
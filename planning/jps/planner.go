@@ -0,0 +1,61 @@
+package jps
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planner.go
+Description:
+
+	Adapts Jump Point Search to the planning.Planner interface.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Planner
+Description:
+
+	A planning.Planner that uses Jump Point Search.
+	It only plans over 8-connected, uniform-cost 2D grids (*grid_graph.Grid).
+	The search is limited by Budget (the zero value means no limits).
+*/
+type Planner struct {
+	Budget planning.Budget
+}
+
+var _ planning.Planner = Planner{}
+
+// =======
+// Methods
+// =======
+
+/*
+Plan
+Description:
+
+	Generates a plan from node start to node goal through the graph g
+	using Jump Point Search. Returns a gppErrors.UnsupportedGraph error
+	if g is not a grid that Jump Point Search can plan over.
+*/
+func (p Planner) Plan(
+	ctx context.Context,
+	g graph.Weighted,
+	start, goal int64,
+) (*planning.Plan, error) {
+	// Input Processing
+	grid, ok := g.(*grid_graph.Grid)
+	if !ok {
+		return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "jump point search needs a *grid_graph.Grid"}
+	}
+
+	// Algorithm
+	return FindPlanWithContext(ctx, grid, start, goal, p.Budget)
+}
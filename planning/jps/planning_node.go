@@ -0,0 +1,46 @@
+package jps
+
+/*
+planning_node.go
+Description:
+
+	Defines the planning node used by Jump Point Search.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+jumpNode
+Description:
+
+	A jump point in the JPS search tree.
+*/
+type jumpNode struct {
+	x, y           int
+	id             int64
+	previousInPlan *jumpNode
+	costToGo       float64
+	heuristicCost  float64
+}
+
+/*
+Cost
+Description:
+
+	Returns the cost to reach the jump point plus the heuristic cost to the end.
+*/
+func (jn *jumpNode) Cost() float64 {
+	return jn.costToGo + jn.heuristicCost
+}
+
+/*
+NodeID
+Description:
+
+	Returns the ID of the grid node of the jump point.
+*/
+func (jn *jumpNode) NodeID() int64 {
+	return jn.id
+}
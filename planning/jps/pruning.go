@@ -0,0 +1,244 @@
+package jps
+
+import (
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"math"
+	"sync"
+)
+
+/*
+pruning.go
+Description:
+
+	Defines the neighbor pruning rules of Jump Point Search.
+
+	A neighbor m of a cell x (reached from its parent p) is pruned if some
+	path from p to m that stays in the 3x3 block around x (and avoids x) is
+	shorter than going through x, or as short if the move from p to x was
+	straight. Instead of hard-coding the resulting rules for each
+	DiagonalPolicy, they are computed once per policy for every possible
+	occupancy of the 3x3 block, using the grid's own movement rules.
+*/
+
+// =========
+// Constants
+// =========
+
+// directions lists the 8 steps in the order used by the direction bit masks.
+var directions = [8][2]int{
+	{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1},
+}
+
+const tolerance = 1e-9
+
+// ================
+// Type Definitions
+// ================
+
+/*
+pruningTable
+Description:
+
+	For one DiagonalPolicy, holds the bit mask of the directions that survive
+	pruning for every occupancy of the 3x3 block around a cell (9 bits)
+	and every direction of arrival (index into directions, or 8 for the start).
+*/
+type pruningTable [512][9]uint8
+
+var (
+	tables     = make(map[grid_graph.DiagonalPolicy]*pruningTable)
+	tablesLock sync.Mutex
+)
+
+// =========
+// Functions
+// =========
+
+/*
+tableFor
+Description:
+
+	Returns the pruning table for the given policy, computing it on first use.
+*/
+func tableFor(policy grid_graph.DiagonalPolicy) *pruningTable {
+	tablesLock.Lock()
+	defer tablesLock.Unlock()
+
+	if table, ok := tables[policy]; ok {
+		return table
+	}
+
+	table := &pruningTable{}
+	for occupancy := 0; occupancy < 512; occupancy++ {
+		block := blockFor(occupancy, policy)
+		for arrival := 0; arrival <= 8; arrival++ {
+			table[occupancy][arrival] = unprunedDirections(block, arrival)
+		}
+	}
+	tables[policy] = table
+
+	return table
+}
+
+/*
+blockFor
+Description:
+
+	Creates a 3x3 grid from an occupancy bit mask, where bit 3*(dy+1) + (dx+1)
+	is set if the cell at offset (dx, dy) from the center is free.
+*/
+func blockFor(occupancy int, policy grid_graph.DiagonalPolicy) *grid_graph.Grid {
+	block := grid_graph.New(3, 3)
+	block.Diagonal = policy
+	for bit := 0; bit < 9; bit++ {
+		if occupancy&(1<<bit) == 0 {
+			block.Block(bit%3, bit/3)
+		}
+	}
+
+	return block
+}
+
+/*
+unprunedDirections
+Description:
+
+	Returns the bit mask of the directions in which the center of block
+	can move after arriving from the direction with index arrival.
+*/
+func unprunedDirections(block *grid_graph.Grid, arrival int) uint8 {
+	// Input Processing
+	if block.IsBlocked(1, 1) {
+		return 0
+	}
+
+	// The start has no parent, so nothing is pruned
+	var mask uint8
+	if arrival == 8 {
+		for idx, d := range directions {
+			if block.CanMove([]int{1, 1}, d[:]) {
+				mask |= 1 << idx
+			}
+		}
+		return mask
+	}
+
+	// Find the shortest paths from the parent to every cell of the block that avoid the center
+	d := directions[arrival]
+	px, py := 1-d[0], 1-d[1]
+	if block.IsBlocked(px, py) {
+		return 0
+	}
+	avoiding := shortestPathsAvoidingCenter(block, px, py)
+
+	// Algorithm
+	for idx, m := range directions {
+		if !block.CanMove([]int{1, 1}, m[:]) {
+			continue
+		}
+
+		through := stepLength(d) + stepLength(m)
+		around := avoiding[1+m[1]][1+m[0]]
+		isDiagonal := d[0] != 0 && d[1] != 0
+
+		if (isDiagonal && around < through-tolerance) || (!isDiagonal && around <= through+tolerance) {
+			continue
+		}
+		mask |= 1 << idx
+	}
+
+	return mask
+}
+
+/*
+shortestPathsAvoidingCenter
+Description:
+
+	Returns the length of the shortest path from the cell {px, py} to every
+	cell of the 3x3 block that does not pass through the center.
+	Unreachable cells have length +Inf.
+*/
+func shortestPathsAvoidingCenter(block *grid_graph.Grid, px, py int) [3][3]float64 {
+	// Setup
+	var dist [3][3]float64
+	for y := range dist {
+		for x := range dist[y] {
+			dist[y][x] = math.Inf(1)
+		}
+	}
+	dist[py][px] = 0.0
+
+	// Relax every move until nothing changes (the block is tiny)
+	for changed := true; changed; {
+		changed = false
+		for y := 0; y < 3; y++ {
+			for x := 0; x < 3; x++ {
+				if (x == 1 && y == 1) || math.IsInf(dist[y][x], 1) {
+					continue
+				}
+
+				for _, m := range directions {
+					nx, ny := x+m[0], y+m[1]
+					if (nx == 1 && ny == 1) || !block.CanMove([]int{x, y}, m[:]) {
+						continue
+					}
+
+					if candidate := dist[y][x] + stepLength(m); candidate < dist[ny][nx]-tolerance {
+						dist[ny][nx] = candidate
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	return dist
+}
+
+/*
+stepLength
+Description:
+
+	Returns the length of a single step in the direction d.
+*/
+func stepLength(d [2]int) float64 {
+	if d[0] != 0 && d[1] != 0 {
+		return math.Sqrt2
+	}
+
+	return 1.0
+}
+
+/*
+naturalDirections
+Description:
+
+	Returns the bit mask of the directions that are never pruned
+	after arriving from the direction with index arrival
+	(the same direction and, for diagonal moves, its two components).
+*/
+func naturalDirections(arrival int) uint8 {
+	mask := uint8(1) << arrival
+	if arrival%2 == 1 {
+		mask |= 1 << ((arrival + 1) % 8)
+		mask |= 1 << ((arrival + 7) % 8)
+	}
+
+	return mask
+}
+
+/*
+directionIndex
+Description:
+
+	Returns the index of the step (dx, dy) in directions.
+*/
+func directionIndex(dx, dy int) int {
+	for idx, d := range directions {
+		if d[0] == dx && d[1] == dy {
+			return idx
+		}
+	}
+
+	return 8
+}
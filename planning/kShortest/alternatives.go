@@ -0,0 +1,330 @@
+package kShortest
+
+import (
+	"cmp"
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+alternatives.go
+Description:
+
+	Defines how dissimilar alternative plans are generated with the penalty method.
+
+	The k shortest plans of a road network usually differ by a single edge.
+	The penalty method instead searches repeatedly for the shortest plan,
+	and after each search multiplies the weights of the edges that the plan
+	used by a penalty, so that the next search prefers other edges.
+	A plan is kept only if it does not share too much of its length with
+	the plans kept before it.
+*/
+
+// =========
+// Constants
+// =========
+
+const (
+	DefaultPenaltyFactor         = 1.5
+	DefaultMaxIterationsPerPlan  = 10
+	DefaultAlternativeMaxOverlap = 0.5
+)
+
+// ================
+// Type Definitions
+// ================
+
+/*
+AlternativeOptions
+Description:
+
+	The settings of FindAlternatives.
+
+	  - K is the number of plans to look for.
+	  - MaxOverlap is the largest fraction of length that two plans may
+	    share (see Overlap), from 0 to 1. A value of 0 requires the plans
+	    to share no edges.
+	  - PenaltyFactor multiplies the weights of the edges of each plan found
+	    (DefaultPenaltyFactor if 0). It must be greater than 1.
+	  - MaxIterations limits the number of searches
+	    (DefaultMaxIterationsPerPlan * K if 0). It must not be negative.
+	  - MaxStretch, if not 0, rejects plans that cost more than MaxStretch
+	    times the cost of the shortest plan.
+	  - Budget limits each search separately.
+*/
+type AlternativeOptions struct {
+	K             int
+	MaxOverlap    float64
+	PenaltyFactor float64
+	MaxIterations int
+	MaxStretch    float64
+	Budget        planning.Budget
+}
+
+/*
+Alternative
+Description:
+
+	A plan returned by FindAlternatives, together with the largest
+	Overlap between it and any of the other plans returned with it.
+*/
+type Alternative struct {
+	Plan    *planning.Plan
+	Overlap float64
+}
+
+// =========
+// Functions
+// =========
+
+/*
+DefaultAlternativeOptions
+Description:
+
+	Returns the options that look for k plans that share
+	at most DefaultAlternativeMaxOverlap of their length.
+*/
+func DefaultAlternativeOptions(k int) AlternativeOptions {
+	return AlternativeOptions{K: k, MaxOverlap: DefaultAlternativeMaxOverlap}
+}
+
+/*
+FindAlternatives
+Description:
+
+	Generates up to options.K dissimilar plans from node start to node end
+	through the graph g with the penalty method. The first plan is the
+	shortest plan, and the plans are ordered from the cheapest to the most
+	expensive. Every pair of plans overlaps by at most options.MaxOverlap.
+
+Notes:
+
+  - The costs of the returned plans are computed with the weights of g
+    (not with the penalized weights).
+  - Fewer than options.K plans are returned if not enough dissimilar
+    plans are found within options.MaxIterations searches.
+  - When a search is stopped early, the plans found so far are returned
+    together with the gppErrors.SearchCancelled or gppErrors.SearchBudgetExceeded error.
+  - If an option is out of range (see AlternativeOptions), the error
+    is a gppErrors.InvalidOption.
+*/
+func FindAlternatives(
+	ctx context.Context,
+	g graph.Weighted,
+	start, end int64,
+	options AlternativeOptions,
+) ([]*Alternative, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+
+	if options.K <= 0 {
+		return nil, nil
+	}
+
+	penaltyFactor := options.PenaltyFactor
+	if penaltyFactor == 0.0 {
+		penaltyFactor = DefaultPenaltyFactor
+	}
+
+	maxIterations := options.MaxIterations
+	if maxIterations == 0 {
+		maxIterations = DefaultMaxIterationsPerPlan * options.K
+	}
+
+	// Setup
+	penalized := &penalizedGraph{Weighted: g, penalties: make(map[[2]int64]float64)}
+	var plans []*planning.Plan
+	var edgeSets []map[[2]int64]float64 // The edges (and their weights) of each kept plan
+	tried := make(map[string]bool)
+
+	// Algorithm
+	for iteration := 0; iteration < maxIterations && len(plans) < options.K; iteration++ {
+		// Find the shortest plan with the current penalties
+		penalizedPlan, err := djikstra.FindPlanWithContext(ctx, penalized, start, end, options.Budget)
+		if err != nil {
+			if len(plans) == 0 {
+				return nil, err
+			}
+			return alternativesFrom(plans, edgeSets), err
+		}
+
+		ids := penalizedPlan.NodeIDs()
+		penalized.penalize(ids, penaltyFactor)
+
+		// Keep the plan if it is new, not too long and not too similar to the kept plans
+		key := planKey(ids)
+		if tried[key] {
+			continue
+		}
+		tried[key] = true
+
+		p := planAlong(g, ids)
+		if len(plans) > 0 && options.MaxStretch > 0 && p.CostToGo > options.MaxStretch*plans[0].CostToGo {
+			continue
+		}
+
+		edges := edgesOf(g, p)
+		similar := false
+		for idx := range plans {
+			if overlap(edges, p.CostToGo, edgeSets[idx], plans[idx].CostToGo) > options.MaxOverlap {
+				similar = true
+				break
+			}
+		}
+		if similar {
+			continue
+		}
+
+		plans = append(plans, p)
+		edgeSets = append(edgeSets, edges)
+	}
+
+	return alternativesFrom(plans, edgeSets), nil
+}
+
+/*
+Overlap
+Description:
+
+	Returns the fraction of length that the plans p and q share:
+	the total cost of the edges used by both plans, divided by the
+	cost of the cheaper plan. Plans with no common edges have an
+	overlap of 0, and identical plans have an overlap of 1.
+	On undirected graphs, an edge counts as shared no matter which
+	direction each plan follows it in.
+*/
+func Overlap(g graph.Weighted, p, q *planning.Plan) float64 {
+	return overlap(edgesOf(g, p), p.CostToGo, edgesOf(g, q), q.CostToGo)
+}
+
+/*
+overlap
+Description:
+
+	Computes Overlap from the edge sets and costs of two plans.
+*/
+func overlap(pEdges map[[2]int64]float64, pCost float64, qEdges map[[2]int64]float64, qCost float64) float64 {
+	// Find the length of the shared edges
+	shared, nShared := 0.0, 0
+	for key, w := range pEdges {
+		if _, ok := qEdges[key]; ok {
+			shared += w
+			nShared++
+		}
+	}
+
+	// Algorithm
+	if nShared == 0 {
+		return 0.0
+	}
+
+	cheaper := min(pCost, qCost)
+	if cheaper <= 0.0 {
+		return 1.0
+	}
+
+	return min(shared/cheaper, 1.0)
+}
+
+/*
+edgesOf
+Description:
+
+	Returns the edges of the plan p (see edgeKey), each with its cost.
+*/
+func edgesOf(g graph.Weighted, p *planning.Plan) map[[2]int64]float64 {
+	ids := p.NodeIDs()
+	edges := make(map[[2]int64]float64, len(p.EdgeCosts))
+	for idx, cost := range p.EdgeCosts {
+		edges[edgeKey(g, ids[idx], ids[idx+1])] += cost
+	}
+
+	return edges
+}
+
+/*
+planAlong
+Description:
+
+	Creates the plan that visits the nodes ids of g in order,
+	with the edge costs of g.
+*/
+func planAlong(g graph.Weighted, ids []int64) *planning.Plan {
+	p := &planning.Plan{Sequence: []graph.Node{g.Node(ids[0])}}
+	for idx := 1; idx < len(ids); idx++ {
+		w, _ := g.Weight(ids[idx-1], ids[idx])
+		p.Sequence = append(p.Sequence, g.Node(ids[idx]))
+		p.EdgeCosts = append(p.EdgeCosts, w)
+		p.CostToGo += w
+	}
+
+	return p
+}
+
+/*
+alternativesFrom
+Description:
+
+	Orders the plans from the cheapest to the most expensive, and
+	reports the largest overlap of each plan with the others.
+*/
+func alternativesFrom(plans []*planning.Plan, edgeSets []map[[2]int64]float64) []*Alternative {
+	// Compute the overlaps
+	alternatives := make([]*Alternative, len(plans))
+	for i := range plans {
+		alternatives[i] = &Alternative{Plan: plans[i]}
+		for j := range plans {
+			if i != j {
+				alternatives[i].Overlap = max(
+					alternatives[i].Overlap,
+					overlap(edgeSets[i], plans[i].CostToGo, edgeSets[j], plans[j].CostToGo),
+				)
+			}
+		}
+	}
+
+	// Algorithm
+	slices.SortStableFunc(alternatives, func(a, b *Alternative) int {
+		return cmp.Compare(a.Plan.CostToGo, b.Plan.CostToGo)
+	})
+
+	return alternatives
+}
+
+// =======
+// Methods
+// =======
+
+/*
+check
+Description:
+
+	Returns a gppErrors.InvalidOption error if MaxOverlap is not in [0, 1],
+	PenaltyFactor is neither 0 nor a finite number greater than 1, or
+	MaxIterations or MaxStretch is negative.
+*/
+func (o AlternativeOptions) check() error {
+	switch {
+	case !(o.MaxOverlap >= 0.0 && o.MaxOverlap <= 1.0):
+		return gppErrors.InvalidOption{Name: "MaxOverlap", Reason: "the overlap must be in [0, 1]"}
+	case o.PenaltyFactor != 0.0 && !(o.PenaltyFactor > 1.0 && !math.IsInf(o.PenaltyFactor, 1)):
+		return gppErrors.InvalidOption{Name: "PenaltyFactor", Reason: "the penalty factor must be a finite number greater than 1"}
+	case o.MaxIterations < 0:
+		return gppErrors.InvalidOption{Name: "MaxIterations", Reason: "the number of searches is negative"}
+	case !(o.MaxStretch >= 0.0):
+		return gppErrors.InvalidOption{Name: "MaxStretch", Reason: "the stretch is negative or NaN"}
+	}
+
+	return nil
+}
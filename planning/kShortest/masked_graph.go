@@ -0,0 +1,137 @@
+package kShortest
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+	"math"
+)
+
+/*
+masked_graph.go
+Description:
+
+	Defines a view of a graph with some of its nodes and edges hidden,
+	which Yen's algorithm uses to search for detours.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+maskedGraph
+Description:
+
+	A graph.Weighted that behaves like g without the nodes in removedNodes
+	and without the edges in removedEdges. Removed edges are directed:
+	hiding {u, v} only stops moves from u to v.
+*/
+type maskedGraph struct {
+	g            graph.Weighted
+	removedNodes map[int64]bool
+	removedEdges map[[2]int64]bool
+}
+
+/*
+newMaskedGraph
+Description:
+
+	Creates a view of g with nothing hidden.
+*/
+func newMaskedGraph(g graph.Weighted) *maskedGraph {
+	return &maskedGraph{
+		g:            g,
+		removedNodes: make(map[int64]bool),
+		removedEdges: make(map[[2]int64]bool),
+	}
+}
+
+/*
+reset
+Description:
+
+	Shows every node and edge of the graph again.
+*/
+func (mg *maskedGraph) reset() {
+	clear(mg.removedNodes)
+	clear(mg.removedEdges)
+}
+
+/*
+isHidden
+Description:
+
+	Returns true if the move from the node with ID uid
+	to the node with ID vid is hidden.
+*/
+func (mg *maskedGraph) isHidden(uid, vid int64) bool {
+	return mg.removedNodes[uid] || mg.removedNodes[vid] || mg.removedEdges[[2]int64{uid, vid}]
+}
+
+// =======
+// Methods
+// =======
+
+func (mg *maskedGraph) Node(id int64) graph.Node {
+	if mg.removedNodes[id] {
+		return nil
+	}
+
+	return mg.g.Node(id)
+}
+
+func (mg *maskedGraph) Nodes() graph.Nodes {
+	var out []graph.Node
+	nodes := mg.g.Nodes()
+	for nodes.Next() {
+		if !mg.removedNodes[nodes.Node().ID()] {
+			out = append(out, nodes.Node())
+		}
+	}
+
+	return iterator.NewOrderedNodes(out)
+}
+
+func (mg *maskedGraph) From(id int64) graph.Nodes {
+	if mg.removedNodes[id] {
+		return graph.Empty
+	}
+
+	var out []graph.Node
+	neighbors := mg.g.From(id)
+	for neighbors.Next() {
+		if !mg.isHidden(id, neighbors.Node().ID()) {
+			out = append(out, neighbors.Node())
+		}
+	}
+
+	return iterator.NewOrderedNodes(out)
+}
+
+func (mg *maskedGraph) HasEdgeBetween(xid, yid int64) bool {
+	if mg.isHidden(xid, yid) && mg.isHidden(yid, xid) {
+		return false
+	}
+
+	return mg.g.HasEdgeBetween(xid, yid)
+}
+
+func (mg *maskedGraph) Edge(uid, vid int64) graph.Edge {
+	return mg.WeightedEdge(uid, vid)
+}
+
+func (mg *maskedGraph) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	if mg.isHidden(uid, vid) {
+		return nil
+	}
+
+	return mg.g.WeightedEdge(uid, vid)
+}
+
+func (mg *maskedGraph) Weight(xid, yid int64) (float64, bool) {
+	if mg.isHidden(xid, yid) {
+		return math.Inf(1), false
+	}
+
+	return mg.g.Weight(xid, yid)
+}
@@ -0,0 +1,87 @@
+package kShortest
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+/*
+penalized_graph.go
+Description:
+
+	Defines a view of a graph where the weights of some edges are
+	multiplied by a penalty, which the penalty method uses to push
+	each new plan away from the plans found before it.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+penalizedGraph
+Description:
+
+	A graph.Weighted that behaves like the embedded graph, except that the
+	weight of each edge in penalties is multiplied by its penalty.
+	On undirected graphs, penalties are stored under edgeKey's key.
+*/
+type penalizedGraph struct {
+	graph.Weighted
+	penalties map[[2]int64]float64
+}
+
+/*
+edgeKey
+Description:
+
+	Returns the key of the edge from the node with ID uid to the node with
+	ID vid in g. Both directions of an undirected edge have the same key.
+*/
+func edgeKey(g graph.Graph, uid, vid int64) [2]int64 {
+	if _, directed := g.(graph.Directed); !directed && vid < uid {
+		return [2]int64{vid, uid}
+	}
+
+	return [2]int64{uid, vid}
+}
+
+// =======
+// Methods
+// =======
+
+/*
+penalize
+Description:
+
+	Multiplies the weight of every edge of the path ids by factor.
+*/
+func (pg *penalizedGraph) penalize(ids []int64, factor float64) {
+	for idx := 0; idx+1 < len(ids); idx++ {
+		key := edgeKey(pg.Weighted, ids[idx], ids[idx+1])
+		if penalty, ok := pg.penalties[key]; ok {
+			pg.penalties[key] = penalty * factor
+			continue
+		}
+		pg.penalties[key] = factor
+	}
+}
+
+func (pg *penalizedGraph) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	e := pg.Weighted.WeightedEdge(uid, vid)
+	if e == nil {
+		return nil
+	}
+
+	w, _ := pg.Weight(uid, vid)
+	return simple.WeightedEdge{F: e.From(), T: e.To(), W: w}
+}
+
+func (pg *penalizedGraph) Weight(xid, yid int64) (float64, bool) {
+	w, ok := pg.Weighted.Weight(xid, yid)
+	if penalty, penalized := pg.penalties[edgeKey(pg.Weighted, xid, yid)]; ok && penalized {
+		w *= penalty
+	}
+
+	return w, ok
+}
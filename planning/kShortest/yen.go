@@ -0,0 +1,179 @@
+package kShortest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"slices"
+)
+
+/*
+yen.go
+Description:
+
+	Defines how the k shortest loopless plans between two nodes are
+	generated with Yen's algorithm.
+
+	Yen's algorithm finds the shortest plan, and then finds each next plan
+	among the "detours" of the plans found so far: for every node of the
+	previous plan (the spur node), it keeps the plan up to that node (the root)
+	and searches for the shortest way from the spur node to the end that
+	neither revisits the root nor leaves the spur node the way that
+	an already-found plan with the same root does.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlans
+Description:
+
+	Generates up to k loopless plans from node start to node end through
+	the graph g, ordered from the cheapest to the most expensive.
+	Fewer than k plans are returned if g does not have k loopless paths
+	from start to end.
+*/
+func FindPlans(
+	g graph.Weighted,
+	start, end int64,
+	k int,
+) ([]*planning.Plan, error) {
+	return FindPlansWithContext(context.Background(), g, start, end, k, planning.Budget{})
+}
+
+/*
+FindPlansWithContext
+Description:
+
+	Generates up to k loopless plans like FindPlans, but stops early if the
+	context ctx is done or if one of the shortest-path searches exceeds
+	one of the limits in budget.
+
+Notes:
+
+  - The budget applies to each shortest-path search separately.
+  - When the search is stopped early, the plans found so far are returned
+    together with the gppErrors.SearchCancelled or gppErrors.SearchBudgetExceeded error.
+  - If there is no path from start to end, the error is a gppErrors.NoPathFound.
+*/
+func FindPlansWithContext(
+	ctx context.Context,
+	g graph.Weighted,
+	start, end int64,
+	k int,
+	budget planning.Budget,
+) ([]*planning.Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	if k <= 0 {
+		return nil, nil
+	}
+
+	// Find the shortest plan
+	shortest, err := djikstra.FindPlanWithContext(ctx, g, start, end, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := []*planning.Plan{shortest}
+	found := map[string]bool{planKey(shortest.NodeIDs()): true}
+	var candidates []*planning.Plan
+
+	// Algorithm
+	masked := newMaskedGraph(g)
+	for len(plans) < k {
+		previous := plans[len(plans)-1]
+		previousIDs := previous.NodeIDs()
+
+		for spurIdx := 0; spurIdx+1 < len(previousIDs); spurIdx++ {
+			rootIDs := previousIDs[:spurIdx+1]
+
+			// Hide the root (except the spur node) and the edges that
+			// the plans with the same root take out of the spur node
+			masked.reset()
+			for _, id := range rootIDs[:spurIdx] {
+				masked.removedNodes[id] = true
+			}
+
+			for _, p := range plans {
+				ids := p.NodeIDs()
+				if len(ids) > spurIdx+1 && slices.Equal(ids[:spurIdx+1], rootIDs) {
+					masked.removedEdges[[2]int64{ids[spurIdx], ids[spurIdx+1]}] = true
+				}
+			}
+
+			// Find the detour from the spur node
+			spur, err := djikstra.FindPlanWithContext(ctx, masked, rootIDs[spurIdx], end, budget)
+			if errors.As(err, &gppErrors.NoPathFound{}) {
+				continue
+			}
+			if err != nil {
+				return plans, err
+			}
+
+			candidate := rootOf(previous, spurIdx).Append(spur)
+			if key := planKey(candidate.NodeIDs()); !found[key] {
+				found[key] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Move the cheapest candidate (the first one found, on ties) to the plans
+		bestIdx := 0
+		for idx, candidate := range candidates {
+			if candidate.CostToGo < candidates[bestIdx].CostToGo {
+				bestIdx = idx
+			}
+		}
+		plans = append(plans, candidates[bestIdx])
+		candidates = slices.Delete(candidates, bestIdx, bestIdx+1)
+	}
+
+	return plans, nil
+}
+
+/*
+rootOf
+Description:
+
+	Returns the part of the plan p up to (and including) the node at index idx.
+*/
+func rootOf(p *planning.Plan, idx int) *planning.Plan {
+	root := &planning.Plan{
+		Sequence:  slices.Clone(p.Sequence[:idx+1]),
+		EdgeCosts: slices.Clone(p.EdgeCosts[:idx]),
+	}
+	for _, cost := range root.EdgeCosts {
+		root.CostToGo += cost
+	}
+
+	return root
+}
+
+/*
+planKey
+Description:
+
+	Returns a string that identifies the sequence of node IDs ids.
+*/
+func planKey(ids []int64) string {
+	key := make([]byte, 0, 8*len(ids))
+	for _, id := range ids {
+		key = binary.LittleEndian.AppendUint64(key, uint64(id))
+	}
+
+	return string(key)
+}
@@ -0,0 +1,376 @@
+package lpaStar
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/internal/incremental"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+planner.go
+Description:
+
+	Defines an incremental planner based on the Lifelong Planning A*
+	(LPA*) algorithm. LPA* repeatedly plans between the same start and
+	goal while edge weights change (e.g., because of traffic updates).
+	It keeps the cost from the start of every node it has searched
+	(its g and rhs values) between queries, so each query only repairs
+	the part of the search that is affected by the changed edges.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Heuristic
+Description:
+
+	Estimates the cost of moving from the node from to the node to.
+	LPA* uses it to estimate the cost from each node to the goal,
+	so it must never overestimate and must satisfy the triangle inequality.
+*/
+type Heuristic func(from, to graph.Node) float64
+
+/*
+Planner
+Description:
+
+	An LPA* planner for a fixed start and goal in a graph.
+	Edge weights are read from the graph, unless they were
+	overridden with UpdateEdge.
+	Each call to Plan is limited by Budget (the zero value means no limits).
+*/
+type Planner struct {
+	Budget planning.Budget
+
+	graph     graph.Weighted
+	reversed  graph.Weighted // The graph with its edges reversed (used to find predecessors)
+	directed  bool
+	heuristic Heuristic
+	start     int64
+	goal      int64
+	weights   map[[2]int64]float64 // The weights set with UpdateEdge
+	state     *incremental.State
+}
+
+// =========
+// Functions
+// =========
+
+/*
+NewPlanner
+Description:
+
+	Creates an LPA* planner that plans from node start to node goal
+	through the graph g. If heuristic is nil, then a heuristic of zero is used.
+	Returns a gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound
+	error if start or goal is not in the graph.
+*/
+func NewPlanner(
+	g graph.Weighted,
+	start, goal int64,
+	heuristic Heuristic,
+) (*Planner, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, goal); err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	_, directed := g.(graph.Directed)
+	p := &Planner{
+		graph:     g,
+		reversed:  planning.Reverse(g),
+		directed:  directed,
+		heuristic: heuristic,
+		start:     start,
+		goal:      goal,
+		weights:   make(map[[2]int64]float64),
+		state:     incremental.NewState(start),
+	}
+	p.state.Open.Push(p.calculateKey(start))
+
+	return p, nil
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Plan
+Description:
+
+	Returns the optimal plan from the start to the goal for the current
+	edge weights, repairing the search of the previous call to Plan.
+
+Notes:
+
+  - If the search is stopped by the context or the budget, the state is
+    kept, so a later call continues the search where it stopped.
+*/
+func (p *Planner) Plan(ctx context.Context) (*planning.Plan, error) {
+	// Algorithm
+	if err := p.computeShortestPath(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.extractPlan()
+}
+
+/*
+UpdateEdge
+Description:
+
+	Sets the weight of the edge from the node with ID from to the node
+	with ID to. Use math.Inf(1) to block the edge. On undirected graphs,
+	the new weight applies in both directions.
+	The graph itself is not modified; the planner uses the new weight
+	instead of the graph's weight in all later calls to Plan.
+	Returns a gppErrors.EdgeNotFound error if there is no such edge in the graph.
+*/
+func (p *Planner) UpdateEdge(from, to int64, newWeight float64) error {
+	// Input Processing
+	if !p.hasEdge(from, to) {
+		return gppErrors.EdgeNotFound{From: from, To: to}
+	}
+
+	// Algorithm
+	p.weights[p.edgeKey(from, to)] = newWeight
+	p.updateVertex(to)
+	if !p.directed {
+		p.updateVertex(from)
+	}
+
+	return nil
+}
+
+/*
+Weight
+Description:
+
+	Returns the weight that the planner uses for the edge from the node
+	with ID xid to the node with ID yid, and whether there is such an edge.
+*/
+func (p *Planner) Weight(xid, yid int64) (float64, bool) {
+	if !p.hasEdge(xid, yid) {
+		return math.Inf(1), false
+	}
+
+	return p.cost(xid, yid), true
+}
+
+/*
+computeShortestPath
+Description:
+
+	Expands inconsistent nodes until the goal is consistent and no
+	node in the open list can improve the cost to the goal.
+*/
+func (p *Planner) computeShortestPath(ctx context.Context) error {
+	// Setup
+	stats := gppErrors.SearchStatistics{MaxHeapSize: p.state.Open.Len()}
+
+	// Algorithm
+	for p.state.Open.Len() > 0 {
+		top := p.state.Open.Min().(*incremental.Key)
+		goalKey := p.calculateKey(p.goal)
+		if !top.Less(goalKey) && p.state.Consistent(p.goal) {
+			break
+		}
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = p.state.Open.Len()
+		stats.FrontierCost = top.K1
+		if err := p.Budget.Check(ctx, stats); err != nil {
+			return err
+		}
+
+		u := top.ID
+		p.state.Open.Remove(u)
+		stats.Expansions++
+
+		if p.state.G(u) > p.state.RHS(u) {
+			// Overconsistent: lower g and update the successors
+			p.state.SetG(u, p.state.RHS(u))
+			p.updateSuccessors(u)
+		} else {
+			// Underconsistent: raise g and update the node and its successors
+			p.state.SetG(u, math.Inf(1))
+			p.updateVertex(u)
+			p.updateSuccessors(u)
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, p.state.Open.Len())
+	}
+
+	return nil
+}
+
+/*
+extractPlan
+Description:
+
+	Follows the cheapest predecessors from the goal back to the start.
+	Returns a gppErrors.NoPathFound error if the goal cannot be reached.
+*/
+func (p *Planner) extractPlan() (*planning.Plan, error) {
+	// Input Processing
+	if math.IsInf(p.state.G(p.goal), 1) {
+		return nil, gppErrors.NoPathFound{Graph: p.graph}
+	}
+
+	// Algorithm
+	reversedPlan := []graph.Node{p.graph.Node(p.goal)}
+	var reversedEdgeCosts []float64
+	visited := map[int64]bool{p.goal: true}
+
+	current := p.goal
+	for current != p.start {
+		// Find the predecessor with the cheapest cost from the start
+		previous, previousEdgeCost := int64(0), math.Inf(1)
+		best := math.Inf(1)
+		predecessors := p.reversed.From(current)
+		for predecessors.Next() {
+			s := predecessors.Node().ID()
+			c := p.cost(s, current)
+			if p.state.G(s)+c < best {
+				previous, previousEdgeCost, best = s, c, p.state.G(s)+c
+			}
+		}
+
+		if math.IsInf(best, 1) || visited[previous] {
+			return nil, gppErrors.NoPathFound{Graph: p.graph}
+		}
+
+		visited[previous] = true
+		reversedPlan = append(reversedPlan, p.graph.Node(previous))
+		reversedEdgeCosts = append(reversedEdgeCosts, previousEdgeCost)
+		current = previous
+	}
+
+	// Return result
+	slices.Reverse(reversedPlan)
+	slices.Reverse(reversedEdgeCosts)
+
+	plan := &planning.Plan{
+		Sequence:  reversedPlan,
+		EdgeCosts: reversedEdgeCosts,
+	}
+	for _, edgeCost := range reversedEdgeCosts {
+		plan.CostToGo += edgeCost
+	}
+
+	return plan, nil
+}
+
+/*
+updateVertex
+Description:
+
+	Recomputes the rhs value of the node u from its predecessors and
+	puts u in the open list if (and only if) it is inconsistent.
+*/
+func (p *Planner) updateVertex(u int64) {
+	// Recompute rhs
+	if u != p.start {
+		rhs := math.Inf(1)
+		predecessors := p.reversed.From(u)
+		for predecessors.Next() {
+			s := predecessors.Node().ID()
+			rhs = min(rhs, p.state.G(s)+p.cost(s, u))
+		}
+		p.state.SetRHS(u, rhs)
+	}
+
+	// Update the open list
+	if p.state.Consistent(u) {
+		p.state.Open.Remove(u)
+	} else {
+		p.state.Open.Update(p.calculateKey(u))
+	}
+}
+
+/*
+updateSuccessors
+Description:
+
+	Calls updateVertex on every node that u has an edge to.
+*/
+func (p *Planner) updateSuccessors(u int64) {
+	successors := p.graph.From(u)
+	for successors.Next() {
+		p.updateVertex(successors.Node().ID())
+	}
+}
+
+/*
+calculateKey
+Description:
+
+	Returns the current key of the node with ID id.
+*/
+func (p *Planner) calculateKey(id int64) *incremental.Key {
+	h := 0.0
+	if p.heuristic != nil {
+		h = p.heuristic(p.graph.Node(id), p.graph.Node(p.goal))
+	}
+
+	return p.state.Key(id, h)
+}
+
+/*
+hasEdge
+Description:
+
+	Returns true if the graph has an edge from the node with ID from
+	to the node with ID to.
+*/
+func (p *Planner) hasEdge(from, to int64) bool {
+	if !planning.HasNode(p.graph, from) || !planning.HasNode(p.graph, to) {
+		return false
+	}
+
+	return p.graph.Edge(from, to) != nil
+}
+
+/*
+edgeKey
+Description:
+
+	Returns the key of the edge from the node with ID from to the node
+	with ID to in the map of updated weights. On undirected graphs,
+	both directions of an edge share a key.
+*/
+func (p *Planner) edgeKey(from, to int64) [2]int64 {
+	if !p.directed && to < from {
+		from, to = to, from
+	}
+
+	return [2]int64{from, to}
+}
+
+/*
+cost
+Description:
+
+	Returns the weight of the edge from the node with ID from to the
+	node with ID to (as set with UpdateEdge, or from the graph otherwise),
+	or +Inf if there is no such edge.
+*/
+func (p *Planner) cost(from, to int64) float64 {
+	if w, ok := p.weights[p.edgeKey(from, to)]; ok {
+		return w
+	}
+
+	w, ok := p.graph.Weight(from, to)
+	if !ok {
+		return math.Inf(1)
+	}
+
+	return w
+}
@@ -0,0 +1,57 @@
+package namoa
+
+/*
+dominance.go
+Description:
+
+	Defines the Pareto dominance relations used to compare cost vectors.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+Dominates
+Description:
+
+	Returns true if the cost vector a dominates the cost vector b,
+	i.e. a is no worse than b in every component and strictly better
+	in at least one.
+*/
+func Dominates(a, b []float64) bool {
+	// Constants
+
+	// Algorithm
+	strictlyBetter := false
+	for idx := range a {
+		if a[idx] > b[idx] {
+			return false
+		}
+		if a[idx] < b[idx] {
+			strictlyBetter = true
+		}
+	}
+
+	return strictlyBetter
+}
+
+/*
+WeaklyDominates
+Description:
+
+	Returns true if the cost vector a is no worse than the cost
+	vector b in every component (i.e., a dominates or equals b).
+*/
+func WeaklyDominates(a, b []float64) bool {
+	// Constants
+
+	// Algorithm
+	for idx := range a {
+		if a[idx] > b[idx] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,291 @@
+package namoa
+
+import (
+	"container/heap"
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"slices"
+)
+
+/*
+plan.go
+Description:
+
+	Defines how Pareto-optimal plans are generated with the
+	multi-objective NAMOA* algorithm.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Plan
+Description:
+
+	A path through a graph whose edges carry a vector of costs.
+*/
+type Plan struct {
+	Sequence  []graph.Node // The sequence of nodes in the path (start @ 0, and end @ len(Sequence) - 1
+	EdgeCosts [][]float64  // EdgeCosts[i] is the cost vector of moving from Sequence[i] to Sequence[i+1]
+	CostToGo  []float64    // The total cost vector of the path
+}
+
+// =========
+// Functions
+// =========
+
+/*
+FindParetoFront
+Description:
+
+	Generates the full set of Pareto-optimal (non-dominated) plans
+	from node start to node end through the graph g using the
+	NAMOA* algorithm. At most one plan is returned for each distinct
+	cost vector.
+
+Notes:
+
+  - The components of the edge cost vectors must not be negative.
+  - heuristic may be nil (which is the same as a heuristic of zero).
+    Otherwise, it must return a vector of the same length as the edge
+    cost vectors that never overestimates any component of the cost
+    to reach the end.
+  - If start or end is not in the graph, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindParetoFront(
+	g planning.VectorWeighted,
+	start, end int64,
+	heuristic func(*PlanningNode) []float64,
+) ([]*Plan, error) {
+	return FindParetoFrontWithContext(context.Background(), g, start, end, heuristic, planning.Budget{})
+}
+
+/*
+FindParetoFrontWithContext
+Description:
+
+	Generates the Pareto front like FindParetoFront, but stops early
+	if the context ctx is done or if the search exceeds one of the
+	limits in budget.
+*/
+func FindParetoFrontWithContext(
+	ctx context.Context,
+	g planning.VectorWeighted,
+	start, end int64,
+	heuristic func(*PlanningNode) []float64,
+	budget planning.Budget,
+) ([]*Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	// Create initial planning node and heap
+	pn0 := &PlanningNode{
+		Graph:            g,
+		CurrentGraphNode: g.Node(start),
+		PreviousInPlan:   nil,
+	}
+	if heuristic != nil {
+		pn0.HeuristicCost = heuristic(pn0)
+	}
+
+	var heap0 planningHeap.PlanningHeap
+	heap.Init(&heap0)
+	heap.Push(&heap0, pn0)
+
+	// The open and closed labels of each graph node and the cost vectors
+	// of the plans that have reached the end.
+	openLabels := map[int64][]*PlanningNode{start: {pn0}}
+	closedLabels := make(map[int64][]*PlanningNode)
+	var solutions []*PlanningNode
+	stats := gppErrors.SearchStatistics{MaxHeapSize: len(heap0)}
+
+	// Algorithm
+	for len(heap0) > 0 {
+		// Pop the top label off the heap, skipping labels that were pruned
+		pn := heap.Pop(&heap0).(*PlanningNode)
+		if pn.pruned {
+			continue
+		}
+
+		currentID := pn.NodeID()
+		openLabels[currentID] = removeLabel(openLabels[currentID], pn)
+
+		// Filter labels that cannot lead to a new Pareto-optimal plan
+		if isWeaklyDominatedBySolution(pn.EstimatedCost(), solutions) {
+			continue
+		}
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = len(heap0)
+		stats.FrontierCost = pn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// If we have reached the end, record the plan
+		if currentID == end {
+			solutions = append(solutions, pn)
+			continue
+		}
+
+		// Otherwise, close and expand the label
+		closedLabels[currentID] = append(closedLabels[currentID], pn)
+		expandedNodes := pn.Expand(heuristic)
+		stats.Expansions++
+
+		for _, newPN := range expandedNodes {
+			newID := newPN.NodeID()
+
+			// Discard the new label if an existing label of the same
+			// graph node is at least as good
+			if isWeaklyDominatedByLabel(newPN.CostToGo, openLabels[newID]) ||
+				isWeaklyDominatedByLabel(newPN.CostToGo, closedLabels[newID]) {
+				continue
+			}
+
+			// Discard the new label if it cannot beat a plan we already have
+			if isWeaklyDominatedBySolution(newPN.EstimatedCost(), solutions) {
+				continue
+			}
+
+			// Remove the existing labels that the new label dominates
+			openLabels[newID] = pruneDominatedLabels(openLabels[newID], newPN.CostToGo)
+			closedLabels[newID] = pruneDominatedLabels(closedLabels[newID], newPN.CostToGo)
+
+			openLabels[newID] = append(openLabels[newID], newPN)
+			heap.Push(&heap0, newPN)
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, len(heap0))
+	}
+
+	// Return the Pareto front
+	if len(solutions) == 0 {
+		return nil, gppErrors.NoPathFound{Graph: g}
+	}
+
+	plans := make([]*Plan, len(solutions))
+	for idx, solution := range solutions {
+		plans[idx] = UnrollPlanFrom(solution)
+	}
+
+	return plans, nil
+}
+
+/*
+UnrollPlanFrom
+Description:
+
+	Unrolls a plan from a given planning node.
+	Hopefully, you try this only on a planning node
+	that reaches the end.
+*/
+func UnrollPlanFrom(pn *PlanningNode) *Plan {
+	// Check to see if plan is empty
+	if pn == nil {
+		return nil
+	}
+
+	// Iterate through each of the nodes in the plan
+	current := pn
+	var reversedPlan []graph.Node
+	var reversedEdgeCosts [][]float64
+	for current != nil {
+		// Add current node (in graph) to plan
+		reversedPlan = append(
+			reversedPlan,
+			current.Graph.Node(current.CurrentGraphNode.ID()),
+		)
+
+		// Add the costs of the edge that led to the current node
+		if current.PreviousInPlan != nil {
+			edgeCosts := make([]float64, len(current.CostToGo))
+			for idx := range edgeCosts {
+				edgeCosts[idx] = current.CostToGo[idx]
+				if idx < len(current.PreviousInPlan.CostToGo) {
+					edgeCosts[idx] -= current.PreviousInPlan.CostToGo[idx]
+				}
+			}
+			reversedEdgeCosts = append(reversedEdgeCosts, edgeCosts)
+		}
+
+		// Update current
+		current = current.PreviousInPlan
+	}
+
+	// Return result
+	forwardPlan := reversedPlan
+	slices.Reverse(forwardPlan)
+
+	forwardEdgeCosts := reversedEdgeCosts
+	slices.Reverse(forwardEdgeCosts)
+
+	return &Plan{
+		Sequence:  forwardPlan,
+		EdgeCosts: forwardEdgeCosts,
+		CostToGo:  slices.Clone(pn.CostToGo),
+	}
+}
+
+/*
+isWeaklyDominatedByLabel
+Description:
+
+	Returns true if the cost vector costs is weakly dominated by the
+	CostToGo of any of the labels.
+*/
+func isWeaklyDominatedByLabel(costs []float64, labels []*PlanningNode) bool {
+	for _, label := range labels {
+		if WeaklyDominates(label.CostToGo, costs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+isWeaklyDominatedBySolution
+Description:
+
+	Returns true if the estimated cost vector costs is weakly dominated
+	by the cost of any plan that has already reached the end.
+*/
+func isWeaklyDominatedBySolution(costs []float64, solutions []*PlanningNode) bool {
+	return isWeaklyDominatedByLabel(costs, solutions)
+}
+
+/*
+pruneDominatedLabels
+Description:
+
+	Removes (and marks as pruned) all labels whose CostToGo is
+	dominated by the cost vector costs.
+*/
+func pruneDominatedLabels(labels []*PlanningNode, costs []float64) []*PlanningNode {
+	return slices.DeleteFunc(labels, func(label *PlanningNode) bool {
+		if Dominates(costs, label.CostToGo) {
+			label.pruned = true
+			return true
+		}
+		return false
+	})
+}
+
+/*
+removeLabel
+Description:
+
+	Removes the label pn from the slice of labels.
+*/
+func removeLabel(labels []*PlanningNode, pn *PlanningNode) []*PlanningNode {
+	return slices.DeleteFunc(labels, func(label *PlanningNode) bool {
+		return label == pn
+	})
+}
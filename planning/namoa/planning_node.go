@@ -0,0 +1,139 @@
+package namoa
+
+import (
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planning_node.go
+Description:
+
+	Defines an implementation of a PlanningNode for use in the NAMOA* algorithm.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+PlanningNode
+Description:
+
+	An implementation of a PlanningNode for use in the NAMOA* algorithm.
+	Each PlanningNode is one "label" of a graph node: a path to the graph
+	node together with the vector of costs of that path.
+*/
+type PlanningNode struct {
+	Graph            planning.VectorWeighted
+	CurrentGraphNode graph.Node
+	PreviousInPlan   *PlanningNode
+	CostToGo         []float64 // The vector of costs from the start node to the current node
+	HeuristicCost    []float64 // The vector of heuristic costs from the current node to the goal node
+	pruned           bool      // True if a dominating label has replaced this one
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Cost
+Description:
+
+	Returns the sum of the components of the estimated total cost vector
+	(CostToGo + HeuristicCost). Popping the label with the smallest sum
+	always selects a label that is not dominated by any other open label.
+*/
+func (pn *PlanningNode) Cost() float64 {
+	// Constants
+
+	// Algorithm
+	total := 0.0
+	for _, c := range pn.EstimatedCost() {
+		total += c
+	}
+
+	return total
+}
+
+/*
+EstimatedCost
+Description:
+
+	Returns the estimated total cost vector (CostToGo + HeuristicCost)
+	of the current planning node.
+*/
+func (pn *PlanningNode) EstimatedCost() []float64 {
+	// Constants
+
+	// Algorithm
+	out := make([]float64, len(pn.CostToGo))
+	copy(out, pn.CostToGo)
+	for idx := range out {
+		if idx < len(pn.HeuristicCost) {
+			out[idx] += pn.HeuristicCost[idx]
+		}
+	}
+
+	return out
+}
+
+/*
+NodeID
+Description:
+
+	Returns the ID of the graph node that this planning node represents.
+*/
+func (pn *PlanningNode) NodeID() int64 {
+	return pn.CurrentGraphNode.ID()
+}
+
+/*
+Expand
+Description:
+
+	"Expands" from the current graph node to all of the adjacent nodes.
+	Returns a slice of PlanningNodes that represent the expanded nodes.
+*/
+func (pn *PlanningNode) Expand(heuristic func(*PlanningNode) []float64) []*PlanningNode {
+	// Setup
+
+	// Create a slice to hold the expanded nodes
+	var expandedNodes []*PlanningNode
+	neighboringNodes := pn.Graph.From(pn.CurrentGraphNode.ID())
+	for neighboringNodes.Next() {
+		// Get current node and the costs of the edge to it
+		node := neighboringNodes.Node()
+		edgeCosts, ok := pn.Graph.CostVector(pn.CurrentGraphNode.ID(), node.ID())
+		if !ok {
+			continue
+		}
+
+		// Create a new planning node for the expanded node
+		expandedNode := &PlanningNode{
+			Graph:            pn.Graph,
+			CurrentGraphNode: node,
+			PreviousInPlan:   pn,
+			CostToGo:         make([]float64, len(edgeCosts)),
+		}
+		for idx := range edgeCosts {
+			expandedNode.CostToGo[idx] = edgeCosts[idx]
+			if idx < len(pn.CostToGo) {
+				expandedNode.CostToGo[idx] += pn.CostToGo[idx]
+			}
+		}
+
+		// Calculate the heuristic costs for the expanded node
+		if heuristic != nil {
+			expandedNode.HeuristicCost = heuristic(expandedNode)
+		}
+
+		// Add the expanded node to the slice
+		expandedNodes = append(expandedNodes, expandedNode)
+	}
+
+	// Return
+	return expandedNodes
+
+}
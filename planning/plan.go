@@ -0,0 +1,101 @@
+package planning
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"slices"
+)
+
+/*
+plan.go
+Description:
+
+	Defines the plan type that is returned by every planner in this module.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Plan
+Description:
+
+	A path through a graph, together with the cost of each of its edges.
+*/
+type Plan struct {
+	Sequence  []graph.Node // The sequence of nodes in the path (start @ 0, and end @ len(Sequence) - 1
+	EdgeCosts []float64    // EdgeCosts[i] is the cost of moving from Sequence[i] to Sequence[i+1]
+	CostToGo  float64      // The total cost of the path
+}
+
+// =======
+// Methods
+// =======
+
+/*
+NodeIDs
+Description:
+
+	Returns the IDs of the nodes in the plan, in order.
+*/
+func (p *Plan) NodeIDs() []int64 {
+	// Constants
+
+	// Algorithm
+	ids := make([]int64, len(p.Sequence))
+	for idx, n := range p.Sequence {
+		ids[idx] = n.ID()
+	}
+
+	return ids
+}
+
+/*
+Reversed
+Description:
+
+	Returns a new plan that visits the nodes of the plan in reverse order.
+	(The edge costs are kept, so this only makes sense on graphs where
+	each edge costs the same in both directions, or when reversing a plan
+	found on a reversed graph.)
+*/
+func (p *Plan) Reversed() *Plan {
+	// Constants
+
+	// Algorithm
+	sequence := slices.Clone(p.Sequence)
+	slices.Reverse(sequence)
+
+	edgeCosts := slices.Clone(p.EdgeCosts)
+	slices.Reverse(edgeCosts)
+
+	return &Plan{
+		Sequence:  sequence,
+		EdgeCosts: edgeCosts,
+		CostToGo:  p.CostToGo,
+	}
+}
+
+/*
+Append
+Description:
+
+	Returns a new plan that follows the plan p and then the plan next.
+	The last node of p must be the first node of next; it appears
+	only once in the result.
+*/
+func (p *Plan) Append(next *Plan) *Plan {
+	// Constants
+
+	// Algorithm
+	sequence := slices.Clone(p.Sequence)
+	if len(next.Sequence) > 0 {
+		sequence = append(sequence, next.Sequence[1:]...)
+	}
+
+	return &Plan{
+		Sequence:  sequence,
+		EdgeCosts: append(slices.Clone(p.EdgeCosts), next.EdgeCosts...),
+		CostToGo:  p.CostToGo + next.CostToGo,
+	}
+}
@@ -0,0 +1,30 @@
+package planning
+
+import (
+	"context"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planner.go
+Description:
+
+	Defines the interface shared by all of the planners in this module,
+	so that algorithms can be swapped without changing the calling code.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Planner
+Description:
+
+	An algorithm that can find a plan from node start to node goal
+	through the graph g. If g is directed, edges are only followed
+	from their From() node to their To() node.
+*/
+type Planner interface {
+	Plan(ctx context.Context, g graph.Weighted, start, goal int64) (*Plan, error)
+}
@@ -0,0 +1,346 @@
+package rcsp
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+plan.go
+Description:
+
+	Defines how resource-constrained shortest plans are generated.
+	The edges of the graph carry a vector of costs (e.g., flight time and
+	battery use). The planner minimizes one component of the vector (the
+	objective) while keeping the totals of the other components (the
+	resources) below their limits.
+
+	The search is a label-setting algorithm: each graph node can hold
+	several labels (partial plans), one for each way of trading the objective
+	against the resources. A label is pruned when another label of the same
+	node is at least as good in the objective and in every constrained
+	resource, or when it cannot reach the goal within the limits.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Constraints
+Description:
+
+	The objective and resource limits of a constrained search.
+	Limits[i] is the largest allowed total of cost component i. Components
+	without a limit (beyond the end of Limits, or with a limit of +Inf)
+	are unconstrained.
+*/
+type Constraints struct {
+	Objective int // The index of the cost component to minimize
+	Limits    []float64
+}
+
+/*
+Plan
+Description:
+
+	A path through a graph whose edges carry a vector of costs.
+*/
+type Plan struct {
+	Sequence  []graph.Node // The sequence of nodes in the path (start @ 0, and end @ len(Sequence) - 1
+	EdgeCosts [][]float64  // EdgeCosts[i] is the cost vector of moving from Sequence[i] to Sequence[i+1]
+	CostToGo  []float64    // The total cost vector of the path
+}
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlan
+Description:
+
+	Generates the plan from node start to node end through the graph g
+	with the smallest total of the objective cost component, among the
+	plans that stay within every limit of the constraints.
+	If heuristic is not nil, it must return a lower bound on each cost
+	component from a label's node to the end; these bounds are used both
+	to guide the search and to prune labels that would exceed a limit.
+
+Notes:
+
+  - If paths from start to end exist but none of them satisfies the
+    constraints, the error is a gppErrors.InfeasibleConstraints.
+    If there is no path at all, the error is a gppErrors.NoPathFound.
+  - Every cost component must be non-negative.
+  - If the objective is negative or not a component of the graph's cost
+    vectors, or a limit is negative or NaN, the error is a
+    gppErrors.InvalidOption.
+*/
+func FindPlan(
+	g planning.VectorWeighted,
+	start, end int64,
+	constraints Constraints,
+	heuristic func(*PlanningNode) []float64,
+) (*Plan, error) {
+	return FindPlanWithContext(context.Background(), g, start, end, constraints, heuristic, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates a constrained plan like FindPlan, but stops early if the
+	context ctx is done or if the search exceeds one of the limits in budget.
+	The frontier cost compared against budget.MaxCost is the estimated
+	objective cost of each label.
+*/
+func FindPlanWithContext(
+	ctx context.Context,
+	g planning.VectorWeighted,
+	start, end int64,
+	constraints Constraints,
+	heuristic func(*PlanningNode) []float64,
+	budget planning.Budget,
+) (*Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	if err := constraints.check(g, start); err != nil {
+		return nil, err
+	}
+
+	// Find the components that labels are compared on
+	components := []int{constraints.Objective}
+	for idx, limit := range constraints.Limits {
+		if idx != constraints.Objective && !math.IsInf(limit, 1) {
+			components = append(components, idx)
+		}
+	}
+
+	// Create initial label and heap
+	pn0 := &PlanningNode{
+		Graph:            g,
+		CurrentGraphNode: g.Node(start),
+		PreviousInPlan:   nil,
+		objective:        constraints.Objective,
+	}
+	if heuristic != nil {
+		pn0.HeuristicCost = heuristic(pn0)
+	}
+
+	var heap0 planningHeap.PlanningHeap
+	heap.Init(&heap0)
+
+	exceededLimit := !constraints.allows(pn0)
+	if !exceededLimit {
+		heap.Push(&heap0, pn0)
+	}
+
+	labels := map[int64][]*PlanningNode{start: {pn0}}
+	stats := gppErrors.SearchStatistics{MaxHeapSize: len(heap0)}
+
+	// Algorithm
+	for len(heap0) > 0 {
+		// Pop the top label off the heap, skipping labels that were pruned
+		pn := heap.Pop(&heap0).(*PlanningNode)
+		if pn.pruned {
+			continue
+		}
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = len(heap0)
+		stats.FrontierCost = pn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// The first label to reach the end has the smallest objective cost
+		if pn.NodeID() == end {
+			return UnrollPlanFrom(pn), nil
+		}
+
+		// Otherwise, expand the label
+		stats.Expansions++
+		for _, newPN := range pn.Expand(heuristic) {
+			// Discard the new label if it cannot reach the end within the limits
+			if !constraints.allows(newPN) {
+				exceededLimit = true
+				continue
+			}
+
+			// Discard the new label if an existing label of the same graph node is at least as good
+			newID := newPN.NodeID()
+			if slices.ContainsFunc(labels[newID], func(label *PlanningNode) bool {
+				return weaklyDominates(label.CostToGo, newPN.CostToGo, components)
+			}) {
+				continue
+			}
+
+			// Remove the existing labels that the new label dominates
+			labels[newID] = slices.DeleteFunc(labels[newID], func(label *PlanningNode) bool {
+				if weaklyDominates(newPN.CostToGo, label.CostToGo, components) {
+					label.pruned = true
+					return true
+				}
+				return false
+			})
+
+			labels[newID] = append(labels[newID], newPN)
+			heap.Push(&heap0, newPN)
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, len(heap0))
+	}
+
+	// Tell apart constraints that cannot be met from a missing path
+	if exceededLimit && isReachable(g, start, end) {
+		return nil, gppErrors.InfeasibleConstraints{Graph: g, Limits: slices.Clone(constraints.Limits)}
+	}
+
+	return nil, gppErrors.NoPathFound{Graph: g}
+}
+
+/*
+UnrollPlanFrom
+Description:
+
+	Unrolls a plan from a given label.
+	Hopefully, you try this only on a label that reaches the end.
+*/
+func UnrollPlanFrom(pn *PlanningNode) *Plan {
+	// Check to see if plan is empty
+	if pn == nil {
+		return nil
+	}
+
+	// Iterate through each of the labels in the plan
+	var reversedPlan []graph.Node
+	var reversedEdgeCosts [][]float64
+	for current := pn; current != nil; current = current.PreviousInPlan {
+		reversedPlan = append(reversedPlan, current.Graph.Node(current.CurrentGraphNode.ID()))
+
+		if current.PreviousInPlan != nil {
+			edgeCosts := make([]float64, len(current.CostToGo))
+			for idx := range edgeCosts {
+				edgeCosts[idx] = current.CostToGo[idx] - component(current.PreviousInPlan.CostToGo, idx)
+			}
+			reversedEdgeCosts = append(reversedEdgeCosts, edgeCosts)
+		}
+	}
+
+	// Return result
+	slices.Reverse(reversedPlan)
+	slices.Reverse(reversedEdgeCosts)
+
+	return &Plan{
+		Sequence:  reversedPlan,
+		EdgeCosts: reversedEdgeCosts,
+		CostToGo:  slices.Clone(pn.CostToGo),
+	}
+}
+
+/*
+check
+Description:
+
+	Returns a gppErrors.InvalidOption error if the objective is negative or
+	beyond the cost vectors of the graph g (as given by an edge leaving the
+	node with ID start, if there is one), or if a limit is negative or NaN.
+*/
+func (c Constraints) check(g planning.VectorWeighted, start int64) error {
+	// Check the objective
+	if c.Objective < 0 {
+		return gppErrors.InvalidOption{Name: "Objective", Reason: "the index of the objective is negative"}
+	}
+
+	neighbors := g.From(start)
+	if neighbors.Next() {
+		if costs, ok := g.CostVector(start, neighbors.Node().ID()); ok && c.Objective >= len(costs) {
+			return gppErrors.InvalidOption{
+				Name:   "Objective",
+				Reason: fmt.Sprintf("the cost vectors of the graph only have %v components", len(costs)),
+			}
+		}
+	}
+
+	// Check the limits
+	for idx, limit := range c.Limits {
+		if limit < 0.0 || math.IsNaN(limit) {
+			return gppErrors.InvalidOption{Name: fmt.Sprintf("Limits[%v]", idx), Reason: "the limit is negative or NaN"}
+		}
+	}
+
+	return nil
+}
+
+/*
+allows
+Description:
+
+	Returns true if the label pn can still reach the end without
+	exceeding a limit (using its heuristic lower bounds).
+*/
+func (c Constraints) allows(pn *PlanningNode) bool {
+	for idx, limit := range c.Limits {
+		if component(pn.CostToGo, idx)+component(pn.HeuristicCost, idx) > limit {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+weaklyDominates
+Description:
+
+	Returns true if the cost vector a is no worse than the cost
+	vector b in each of the given components.
+*/
+func weaklyDominates(a, b []float64, components []int) bool {
+	for _, idx := range components {
+		if component(a, idx) > component(b, idx) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+isReachable
+Description:
+
+	Returns true if some path leads from node start to node end in g,
+	ignoring all costs.
+*/
+func isReachable(g graph.Graph, start, end int64) bool {
+	visited := map[int64]bool{start: true}
+	frontier := []int64{start}
+	for len(frontier) > 0 {
+		id := frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		if id == end {
+			return true
+		}
+
+		neighbors := g.From(id)
+		for neighbors.Next() {
+			if next := neighbors.Node().ID(); !visited[next] {
+				visited[next] = true
+				frontier = append(frontier, next)
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package rcsp
+
+import (
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planning_node.go
+Description:
+
+	Defines the labels used by the resource-constrained shortest path planner.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+PlanningNode
+Description:
+
+	A label: one path from the start to CurrentGraphNode, with the total
+	of each cost component along it. A graph node can have several labels
+	when none of them is better than the others in every constrained component.
+*/
+type PlanningNode struct {
+	Graph            planning.VectorWeighted
+	CurrentGraphNode graph.Node
+	PreviousInPlan   *PlanningNode
+	CostToGo         []float64 // The vector of costs from the start node to the current node
+	HeuristicCost    []float64 // Lower bounds on the vector of costs from the current node to the goal node
+	objective        int       // The index of the cost component that is minimized
+	pruned           bool      // True if a dominating label has replaced this one
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Cost
+Description:
+
+	Returns the estimated total of the objective cost component,
+	which orders the labels in the heap.
+*/
+func (pn *PlanningNode) Cost() float64 {
+	return component(pn.CostToGo, pn.objective) + component(pn.HeuristicCost, pn.objective)
+}
+
+/*
+NodeID
+Description:
+
+	Returns the ID of the graph node that this planning node represents.
+*/
+func (pn *PlanningNode) NodeID() int64 {
+	return pn.CurrentGraphNode.ID()
+}
+
+/*
+Expand
+Description:
+
+	"Expands" from the current graph node to all of the adjacent nodes.
+	Returns a slice of PlanningNodes that represent the expanded nodes.
+*/
+func (pn *PlanningNode) Expand(heuristic func(*PlanningNode) []float64) []*PlanningNode {
+	// Create a slice to hold the expanded nodes
+	var expandedNodes []*PlanningNode
+	neighboringNodes := pn.Graph.From(pn.CurrentGraphNode.ID())
+	for neighboringNodes.Next() {
+		// Get current node and the costs of the edge to it
+		node := neighboringNodes.Node()
+		edgeCosts, ok := pn.Graph.CostVector(pn.CurrentGraphNode.ID(), node.ID())
+		if !ok {
+			continue
+		}
+
+		// Create a new planning node for the expanded node
+		expandedNode := &PlanningNode{
+			Graph:            pn.Graph,
+			CurrentGraphNode: node,
+			PreviousInPlan:   pn,
+			CostToGo:         make([]float64, max(len(edgeCosts), len(pn.CostToGo))),
+			objective:        pn.objective,
+		}
+		for idx := range expandedNode.CostToGo {
+			expandedNode.CostToGo[idx] = component(edgeCosts, idx) + component(pn.CostToGo, idx)
+		}
+
+		// Calculate the heuristic costs for the expanded node
+		if heuristic != nil {
+			expandedNode.HeuristicCost = heuristic(expandedNode)
+		}
+
+		expandedNodes = append(expandedNodes, expandedNode)
+	}
+
+	return expandedNodes
+}
+
+/*
+component
+Description:
+
+	Returns the cost component idx of the vector costs
+	(0 if the vector is too short to have it).
+*/
+func component(costs []float64, idx int) float64 {
+	if idx < len(costs) {
+		return costs[idx]
+	}
+
+	return 0.0
+}
@@ -0,0 +1,115 @@
+package planning
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+/*
+reverse.go
+Description:
+
+	Defines a view of a graph with all of its edges reversed. Planners
+	use it to search backwards from the goal (e.g., in bidirectional search).
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+WeightedDirected
+Description:
+
+	A weighted graph that can also list the predecessors of a node.
+*/
+type WeightedDirected interface {
+	graph.Weighted
+	graph.Directed
+}
+
+/*
+reversedGraph
+Description:
+
+	A view of a directed graph in which every edge points the other way.
+*/
+type reversedGraph struct {
+	g WeightedDirected
+}
+
+// =========
+// Functions
+// =========
+
+/*
+Reverse
+Description:
+
+	Returns a view of the graph g in which every edge points the other way.
+	If g is not directed (i.e., it does not implement graph.Directed),
+	then every edge can already be followed in both directions and g
+	itself is returned.
+*/
+func Reverse(g graph.Weighted) graph.Weighted {
+	// Input Processing
+	if rg, ok := g.(*reversedGraph); ok {
+		return rg.g
+	}
+
+	directed, ok := g.(WeightedDirected)
+	if !ok {
+		return g
+	}
+
+	// Algorithm
+	return &reversedGraph{g: directed}
+}
+
+// =======
+// Methods
+// =======
+
+func (rg *reversedGraph) Node(id int64) graph.Node           { return rg.g.Node(id) }
+func (rg *reversedGraph) Nodes() graph.Nodes                 { return rg.g.Nodes() }
+func (rg *reversedGraph) From(id int64) graph.Nodes          { return rg.g.To(id) }
+func (rg *reversedGraph) To(id int64) graph.Nodes            { return rg.g.From(id) }
+func (rg *reversedGraph) HasEdgeBetween(xid, yid int64) bool { return rg.g.HasEdgeBetween(xid, yid) }
+func (rg *reversedGraph) HasEdgeFromTo(uid, vid int64) bool  { return rg.g.HasEdgeFromTo(vid, uid) }
+func (rg *reversedGraph) Weight(xid, yid int64) (float64, bool) {
+	return rg.g.Weight(yid, xid)
+}
+
+/*
+Edge
+Description:
+
+	Returns the edge from the node with ID uid to the node with ID vid
+	in the reversed graph (i.e., the reversed edge from vid to uid in the
+	original graph), or nil if there is no such edge.
+*/
+func (rg *reversedGraph) Edge(uid, vid int64) graph.Edge {
+	return rg.WeightedEdge(uid, vid)
+}
+
+/*
+WeightedEdge
+Description:
+
+	Returns the weighted edge from the node with ID uid to the node with
+	ID vid in the reversed graph, or nil if there is no such edge.
+*/
+func (rg *reversedGraph) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	// Collect the original edge
+	original := rg.g.WeightedEdge(vid, uid)
+	if original == nil {
+		return nil
+	}
+
+	// Reverse it
+	return simple.WeightedEdge{
+		F: original.To(),
+		T: original.From(),
+		W: original.Weight(),
+	}
+}
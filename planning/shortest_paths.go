@@ -0,0 +1,126 @@
+package planning
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+shortest_paths.go
+Description:
+
+	Defines Djikstra's algorithm over a whole graph from one or more
+	sources, for the tools that need the distance to every node
+	(e.g., landmark tables and checks of heuristics) rather than a plan.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+ShortestPathTree
+Description:
+
+	The distances from the sources to every node they can reach, and
+	the node before each node on a shortest path (sources have none).
+*/
+type ShortestPathTree struct {
+	Distances map[int64]float64
+	Parents   map[int64]int64
+}
+
+/*
+distanceLabel
+Description:
+
+	A node and its tentative distance from the sources.
+*/
+type distanceLabel struct {
+	id       int64
+	distance float64
+}
+
+func (dl *distanceLabel) Cost() float64 {
+	return dl.distance
+}
+
+func (dl *distanceLabel) NodeID() int64 {
+	return dl.id
+}
+
+// =========
+// Functions
+// =========
+
+/*
+ShortestPaths
+Description:
+
+	Runs Djikstra's algorithm from all of the sources at once through the
+	graph g, and stops early (with a gppErrors.SearchCancelled error) if
+	the context ctx is done. Returns a gppErrors.UnsupportedGraph error if
+	the search reaches an edge with a negative weight.
+	Use Reverse(g) to find the distances to the sources instead.
+*/
+func ShortestPaths(ctx context.Context, g graph.Weighted, sources ...int64) (*ShortestPathTree, error) {
+	// Setup
+	tree := &ShortestPathTree{
+		Distances: make(map[int64]float64),
+		Parents:   make(map[int64]int64),
+	}
+
+	open := planningHeap.NewIndexedPlanningHeap()
+	for _, source := range sources {
+		if !open.Contains(source) {
+			open.Push(&distanceLabel{id: source, distance: 0.0})
+		}
+	}
+
+	closed := make(map[int64]bool)
+	stats := gppErrors.SearchStatistics{MaxHeapSize: open.Len()}
+
+	// Algorithm
+	for open.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, gppErrors.SearchCancelled{Cause: err, Statistics: stats}
+		}
+
+		current := open.PopMin().(*distanceLabel)
+		closed[current.id] = true
+		tree.Distances[current.id] = current.distance
+		stats.Expansions++
+
+		neighbors := g.From(current.id)
+		for neighbors.Next() {
+			next := neighbors.Node().ID()
+			if closed[next] {
+				continue
+			}
+
+			w, ok := g.Weight(current.id, next)
+			if !ok {
+				continue
+			}
+			if w < 0.0 {
+				return nil, gppErrors.UnsupportedGraph{Graph: g, Reason: "shortest paths need non-negative edge weights"}
+			}
+
+			candidate := &distanceLabel{id: next, distance: current.distance + w}
+			if !open.Contains(next) {
+				open.Push(candidate)
+				tree.Parents[next] = current.id
+				continue
+			}
+
+			if open.DecreaseKey(candidate) {
+				tree.Parents[next] = current.id
+			}
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, open.Len())
+	}
+
+	return tree, nil
+}
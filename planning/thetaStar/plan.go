@@ -0,0 +1,363 @@
+package thetaStar
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"math"
+	"slices"
+)
+
+/*
+plan.go
+Description:
+
+	Defines how any-angle plans are generated with Theta* and Lazy Theta*.
+	Both are variants of A* where a node's parent may be the parent of the
+	node it was reached from, whenever the straight segment between them
+	is free. Following those shortcuts removes the zig-zags of plans that
+	must follow the graph's edges (e.g., on grids).
+
+	Theta* checks the line of sight every time a node is reached.
+	Lazy Theta* assumes that the line of sight exists and only checks it
+	when the node is expanded, which needs far fewer checks.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlan
+Description:
+
+	Generates an any-angle plan using Theta*.
+	To move from node start to node end through the graph g,
+	where lineOfSight says which straight segments are free.
+
+Notes:
+
+  - Consecutive nodes of the plan are not necessarily neighbors in g.
+    The edge costs of the plan are the costs of the straight segments.
+  - Plans are usually shorter than A*'s plans, but are not guaranteed
+    to be the shortest any-angle plans.
+*/
+func FindPlan(
+	g graph.Weighted,
+	start, end int64,
+	heuristic Heuristic,
+	lineOfSight LineOfSight,
+) (*planning.Plan, error) {
+	return FindPlanWithContext(context.Background(), g, start, end, heuristic, lineOfSight, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates an any-angle plan using Theta*, like FindPlan,
+	but stops early if the context ctx is done or if the search
+	exceeds one of the limits in budget.
+*/
+func FindPlanWithContext(
+	ctx context.Context,
+	g graph.Weighted,
+	start, end int64,
+	heuristic Heuristic,
+	lineOfSight LineOfSight,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	s := &search{graph: g, heuristic: heuristic, lineOfSight: lineOfSight}
+	return s.findPlan(ctx, start, end, budget)
+}
+
+/*
+FindPlanLazy
+Description:
+
+	Generates an any-angle plan using Lazy Theta*.
+	To move from node start to node end through the graph g,
+	where lineOfSight says which straight segments are free.
+	Until a segment is checked, its cost is assumed to be the heuristic
+	cost between its ends, so a good heuristic saves many checks.
+*/
+func FindPlanLazy(
+	g graph.Weighted,
+	start, end int64,
+	heuristic Heuristic,
+	lineOfSight LineOfSight,
+) (*planning.Plan, error) {
+	return FindPlanLazyWithContext(context.Background(), g, start, end, heuristic, lineOfSight, planning.Budget{})
+}
+
+/*
+FindPlanLazyWithContext
+Description:
+
+	Generates an any-angle plan using Lazy Theta*, like FindPlanLazy,
+	but stops early if the context ctx is done or if the search
+	exceeds one of the limits in budget.
+*/
+func FindPlanLazyWithContext(
+	ctx context.Context,
+	g graph.Weighted,
+	start, end int64,
+	heuristic Heuristic,
+	lineOfSight LineOfSight,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	s := &search{graph: g, heuristic: heuristic, lineOfSight: lineOfSight, lazy: true}
+	return s.findPlan(ctx, start, end, budget)
+}
+
+// =======
+// Objects
+// =======
+
+/*
+search
+Description:
+
+	The state of a single Theta* or Lazy Theta* search.
+*/
+type search struct {
+	graph       graph.Weighted
+	reversed    graph.Weighted // The graph with its edges reversed (used to find predecessors)
+	heuristic   Heuristic
+	lineOfSight LineOfSight
+	lazy        bool
+
+	goal   graph.Node
+	nodes  map[int64]*thetaNode
+	closed map[int64]bool
+	open   *planningHeap.IndexedPlanningHeap
+}
+
+/*
+findPlan
+Description:
+
+	Runs the search from node start to node end.
+*/
+func (s *search) findPlan(
+	ctx context.Context,
+	start, end int64,
+	budget planning.Budget,
+) (*planning.Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(s.graph, start, end); err != nil {
+		return nil, err
+	}
+
+	// Without a line of sight, no shortcuts are taken
+	if s.lineOfSight == nil {
+		s.lineOfSight = noLineOfSight
+	}
+
+	// Create initial planning node and heap
+	s.reversed = planning.Reverse(s.graph)
+	s.goal = s.graph.Node(end)
+	s.nodes = make(map[int64]*thetaNode)
+	s.closed = make(map[int64]bool)
+	s.open = planningHeap.NewIndexedPlanningHeap()
+
+	tn0 := s.nodeFor(start)
+	tn0.costToGo = 0.0
+	tn0.verified = true
+	s.open.Push(tn0)
+
+	stats := gppErrors.SearchStatistics{MaxHeapSize: s.open.Len()}
+
+	// Algorithm
+	for s.open.Len() > 0 {
+		// Pop the top node off the heap
+		tn := s.open.PopMin().(*thetaNode)
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = s.open.Len()
+		stats.FrontierCost = tn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// Lazy Theta* checks the segment to the parent now, and puts
+		// the node back if it turned out to be more expensive than assumed
+		if !tn.verified && s.verify(tn) {
+			if !math.IsInf(tn.costToGo, 1) {
+				s.open.Push(tn)
+			}
+			continue
+		}
+
+		// If we have reached the end, return the plan
+		if tn.id == end {
+			return unrollPlanFrom(s.graph, tn), nil
+		}
+
+		// Otherwise, close and expand the node
+		s.closed[tn.id] = true
+		stats.Expansions++
+
+		neighbors := s.graph.From(tn.id)
+		for neighbors.Next() {
+			if id := neighbors.Node().ID(); !s.closed[id] {
+				s.relax(tn, id)
+			}
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, s.open.Len())
+	}
+
+	return nil, gppErrors.NoPathFound{Graph: s.graph}
+}
+
+/*
+nodeFor
+Description:
+
+	Returns the planning node of the graph node with the given ID,
+	creating it (with an infinite cost to go) if it has not been reached yet.
+*/
+func (s *search) nodeFor(id int64) *thetaNode {
+	if tn, ok := s.nodes[id]; ok {
+		return tn
+	}
+
+	tn := &thetaNode{
+		id:            id,
+		costToGo:      math.Inf(1),
+		heuristicCost: s.heuristicCost(id, s.goal.ID()),
+	}
+	s.nodes[id] = tn
+
+	return tn
+}
+
+/*
+heuristicCost
+Description:
+
+	Returns the heuristic cost from the node with ID from to the
+	node with ID to (zero if the search has no heuristic).
+*/
+func (s *search) heuristicCost(from, to int64) float64 {
+	if s.heuristic == nil {
+		return 0.0
+	}
+
+	return s.heuristic(s.graph.Node(from), s.graph.Node(to))
+}
+
+/*
+relax
+Description:
+
+	Tries to lower the cost of the graph node with ID id, which is a
+	neighbor of the planning node tn: either through the edge from tn,
+	or through a straight segment from tn's parent.
+*/
+func (s *search) relax(tn *thetaNode, id int64) {
+	// Path 1: follow the edge from tn
+	w, _ := s.graph.Weight(tn.id, id)
+	cost, parent, verified := tn.costToGo+w, tn, true
+
+	// Path 2: take a shortcut from tn's parent
+	if grandparent := tn.previousInPlan; grandparent != nil {
+		if s.lazy {
+			if assumed := grandparent.costToGo + s.heuristicCost(grandparent.id, id); assumed < cost {
+				cost, parent, verified = assumed, grandparent, false
+			}
+		} else if segment, ok := s.lineOfSight(grandparent.id, id); ok && grandparent.costToGo+segment < cost {
+			cost, parent = grandparent.costToGo+segment, grandparent
+		}
+	}
+
+	// Update the node if the new route is cheaper
+	next := s.nodeFor(id)
+	if cost >= next.costToGo {
+		return
+	}
+
+	next.costToGo, next.previousInPlan, next.verified = cost, parent, verified
+	s.open.Update(next)
+}
+
+/*
+verify
+Description:
+
+	Checks the straight segment from the parent of tn, which Lazy Theta*
+	assumed to be free, and finds tn's real cost and parent.
+	If the segment is blocked (or expensive), the cheapest edge from one
+	of tn's closed predecessors is used instead.
+	Returns true if the cost of tn went up.
+*/
+func (s *search) verify(tn *thetaNode) bool {
+	// Setup
+	tn.verified = true
+	cost, parent := math.Inf(1), tn.previousInPlan
+
+	// Algorithm
+	if segment, ok := s.lineOfSight(parent.id, tn.id); ok {
+		cost = parent.costToGo + segment
+	}
+
+	predecessors := s.reversed.From(tn.id)
+	for predecessors.Next() {
+		id := predecessors.Node().ID()
+		if !s.closed[id] {
+			continue
+		}
+
+		w, _ := s.graph.Weight(id, tn.id)
+		if candidate := s.nodes[id].costToGo + w; candidate < cost {
+			cost, parent = candidate, s.nodes[id]
+		}
+	}
+
+	increased := cost > tn.costToGo
+	tn.costToGo, tn.previousInPlan = cost, parent
+
+	return increased
+}
+
+/*
+noLineOfSight
+Description:
+
+	The LineOfSight used when none is given: no segment is free.
+*/
+func noLineOfSight(_, _ int64) (float64, bool) {
+	return 0.0, false
+}
+
+/*
+unrollPlanFrom
+Description:
+
+	Unrolls a plan from the planning node tn.
+	Each edge cost is the cost of the straight segment
+	between consecutive nodes of the plan.
+*/
+func unrollPlanFrom(g graph.Weighted, tn *thetaNode) *planning.Plan {
+	// Collect the nodes from the end back to the start
+	var sequence []graph.Node
+	var edgeCosts []float64
+	for current := tn; current != nil; current = current.previousInPlan {
+		sequence = append(sequence, g.Node(current.id))
+		if current.previousInPlan != nil {
+			edgeCosts = append(edgeCosts, current.costToGo-current.previousInPlan.costToGo)
+		}
+	}
+
+	// Algorithm
+	slices.Reverse(sequence)
+	slices.Reverse(edgeCosts)
+
+	return &planning.Plan{
+		Sequence:  sequence,
+		EdgeCosts: edgeCosts,
+		CostToGo:  tn.costToGo,
+	}
+}
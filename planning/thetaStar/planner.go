@@ -0,0 +1,81 @@
+package thetaStar
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planner.go
+Description:
+
+	Adapts Theta* and Lazy Theta* to the planning.Planner interface.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Heuristic
+Description:
+
+	Estimates the cost of moving from the node from to the node to.
+	It must never overestimate. Lazy Theta* also uses it as the cost of
+	a straight segment before the segment has been checked, so it must be
+	a lower bound for any pair of nodes (the straight-line distance usually is).
+*/
+type Heuristic func(from, to graph.Node) float64
+
+/*
+LineOfSight
+Description:
+
+	Returns the cost of moving in a straight line from the node with ID from
+	to the node with ID to, and false if the segment between them is not free.
+	(For grids, see the LineOfSight method of grid_graph.Grid.)
+	A nil LineOfSight has no free segments, so no shortcuts are taken.
+*/
+type LineOfSight func(from, to int64) (float64, bool)
+
+/*
+Planner
+Description:
+
+	A planning.Planner that uses Theta*, or Lazy Theta* if Lazy is true.
+	If Heuristic is nil, then a heuristic of zero is used.
+	If LineOfSight is nil, then no shortcuts are taken and the planner
+	behaves like A*. The search is limited by Budget (the zero value means no limits).
+*/
+type Planner struct {
+	Heuristic   Heuristic
+	LineOfSight LineOfSight
+	Lazy        bool
+	Budget      planning.Budget
+}
+
+var _ planning.Planner = Planner{}
+
+// =======
+// Methods
+// =======
+
+/*
+Plan
+Description:
+
+	Generates an any-angle plan from node start to node goal through the graph g.
+*/
+func (p Planner) Plan(
+	ctx context.Context,
+	g graph.Weighted,
+	start, goal int64,
+) (*planning.Plan, error) {
+	// Algorithm
+	if p.Lazy {
+		return FindPlanLazyWithContext(ctx, g, start, goal, p.Heuristic, p.LineOfSight, p.Budget)
+	}
+
+	return FindPlanWithContext(ctx, g, start, goal, p.Heuristic, p.LineOfSight, p.Budget)
+}
@@ -0,0 +1,48 @@
+package thetaStar
+
+/*
+planning_node.go
+Description:
+
+	Defines the planning node used by the Theta* planners.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+thetaNode
+Description:
+
+	A graph node in the Theta* search tree. Unlike in A*, the parent of a
+	node does not have to be one of its neighbors in the graph: it can be
+	any node that the straight segment to this node is free from.
+*/
+type thetaNode struct {
+	id             int64
+	previousInPlan *thetaNode
+	costToGo       float64
+	heuristicCost  float64
+	verified       bool // False if the segment from previousInPlan has not been checked yet (Lazy Theta*)
+}
+
+/*
+Cost
+Description:
+
+	Returns the cost to reach the node plus the heuristic cost to the goal.
+*/
+func (tn *thetaNode) Cost() float64 {
+	return tn.costToGo + tn.heuristicCost
+}
+
+/*
+NodeID
+Description:
+
+	Returns the ID of the graph node that this planning node represents.
+*/
+func (tn *thetaNode) NodeID() int64 {
+	return tn.id
+}
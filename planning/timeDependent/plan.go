@@ -0,0 +1,185 @@
+package timeDependent
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"gonum.org/v1/gonum/graph"
+	"slices"
+)
+
+/*
+plan.go
+Description:
+
+	Defines how plans are generated on graphs with time-dependent travel
+	times. The search is Djikstra's algorithm (or A*, with a heuristic)
+	where each edge is entered at the time its tail node is reached.
+	On graphs that satisfy the FIFO property, the returned plan
+	arrives as early as possible.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+Plan
+Description:
+
+	A plan through a time-dependent graph. The edge costs are the travel
+	times of the edges at the times they are entered, and CostToGo is the
+	total travel time.
+*/
+type Plan struct {
+	planning.Plan
+	Arrivals []float64 // Arrivals[i] is the time at which Sequence[i] is reached (Arrivals[0] is the departure time)
+}
+
+// =========
+// Functions
+// =========
+
+/*
+FindPlan
+Description:
+
+	Generates the plan that reaches node end as early as possible
+	when leaving node start at time departure, through the graph g.
+	If heuristic is not nil, the search is A*, and heuristic must never
+	overestimate the remaining travel time at any time of day (e.g., use
+	the smallest travel time of each profile).
+*/
+func FindPlan(
+	g planning.TimeDependent,
+	start, end int64,
+	departure float64,
+	heuristic func(*PlanningNode) float64,
+) (*Plan, error) {
+	return FindPlanWithContext(context.Background(), g, start, end, departure, heuristic, planning.Budget{})
+}
+
+/*
+FindPlanWithContext
+Description:
+
+	Generates a time-dependent plan like FindPlan, but stops early if the
+	context ctx is done or if the search exceeds one of the limits in budget.
+	The frontier cost compared against budget.MaxCost is the estimated travel time.
+
+Notes:
+
+  - If start or end is not in the graph, the error is a
+    gppErrors.StartNodeNotFound or a gppErrors.GoalNodeNotFound.
+*/
+func FindPlanWithContext(
+	ctx context.Context,
+	g planning.TimeDependent,
+	start, end int64,
+	departure float64,
+	heuristic func(*PlanningNode) float64,
+	budget planning.Budget,
+) (*Plan, error) {
+	// Input Processing
+	if err := planning.CheckEndpoints(g, start, end); err != nil {
+		return nil, err
+	}
+
+	// Create initial planning node and heap
+	pn0 := &PlanningNode{
+		Graph:            g,
+		CurrentGraphNode: g.Node(start),
+		PreviousInPlan:   nil,
+		Departure:        departure,
+		Arrival:          departure,
+	}
+
+	open := planningHeap.NewIndexedPlanningHeap()
+	open.Push(pn0)
+
+	closed := make(map[int64]bool)
+	stats := gppErrors.SearchStatistics{MaxHeapSize: open.Len()}
+
+	// Algorithm
+	for open.Len() > 0 {
+		// Pop the top node off the heap
+		pn := open.PopMin().(*PlanningNode)
+		currentID := pn.NodeID()
+
+		// Stop if we have run out of time or budget
+		stats.HeapSize = open.Len()
+		stats.FrontierCost = pn.Cost()
+		if err := budget.Check(ctx, stats); err != nil {
+			return nil, err
+		}
+
+		// If we have reached the end, return the plan
+		if currentID == end {
+			return UnrollPlanFrom(pn), nil
+		}
+
+		// Otherwise, close and expand the node
+		closed[currentID] = true
+		expandedNodes := pn.Expand(heuristic)
+		stats.Expansions++
+
+		for _, newPN := range expandedNodes {
+			newID := newPN.NodeID()
+			if closed[newID] {
+				continue
+			}
+
+			if !open.Contains(newID) {
+				open.Push(newPN)
+				continue
+			}
+
+			open.DecreaseKey(newPN)
+		}
+		stats.MaxHeapSize = max(stats.MaxHeapSize, open.Len())
+	}
+
+	return nil, gppErrors.NoPathFound{Graph: g}
+}
+
+/*
+UnrollPlanFrom
+Description:
+
+	Unrolls a plan from a given planning node.
+	Hopefully, you try this only on a planning node
+	that reaches the end.
+*/
+func UnrollPlanFrom(pn *PlanningNode) *Plan {
+	// Check to see if plan is empty
+	if pn == nil {
+		return nil
+	}
+
+	// Iterate through each of the nodes in the plan
+	var reversedPlan []graph.Node
+	var reversedArrivals, reversedEdgeCosts []float64
+	for current := pn; current != nil; current = current.PreviousInPlan {
+		reversedPlan = append(reversedPlan, current.Graph.Node(current.CurrentGraphNode.ID()))
+		reversedArrivals = append(reversedArrivals, current.Arrival)
+
+		if current.PreviousInPlan != nil {
+			reversedEdgeCosts = append(reversedEdgeCosts, current.Arrival-current.PreviousInPlan.Arrival)
+		}
+	}
+
+	// Return result
+	slices.Reverse(reversedPlan)
+	slices.Reverse(reversedArrivals)
+	slices.Reverse(reversedEdgeCosts)
+
+	return &Plan{
+		Plan: planning.Plan{
+			Sequence:  reversedPlan,
+			EdgeCosts: reversedEdgeCosts,
+			CostToGo:  pn.Arrival - pn.Departure,
+		},
+		Arrivals: reversedArrivals,
+	}
+}
@@ -0,0 +1,96 @@
+package timeDependent
+
+import (
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+planning_node.go
+Description:
+
+	Defines the planning node used by the time-dependent planner.
+*/
+
+// =======
+// Objects
+// =======
+
+/*
+PlanningNode
+Description:
+
+	An implementation of a PlanningNode for time-dependent planning,
+	which records when the graph node is reached.
+*/
+type PlanningNode struct {
+	Graph            planning.TimeDependent
+	CurrentGraphNode graph.Node
+	PreviousInPlan   *PlanningNode
+	Departure        float64 // The time at which the plan leaves the start node
+	Arrival          float64 // The time at which the plan reaches the current node
+	HeuristicCost    float64 // The heuristic travel time from the current node to the goal node
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Cost
+Description:
+
+	Returns the travel time from the start to the current node
+	plus the heuristic travel time to the goal node.
+*/
+func (pn *PlanningNode) Cost() float64 {
+	return pn.Arrival - pn.Departure + pn.HeuristicCost
+}
+
+/*
+NodeID
+Description:
+
+	Returns the ID of the graph node that this planning node represents.
+*/
+func (pn *PlanningNode) NodeID() int64 {
+	return pn.CurrentGraphNode.ID()
+}
+
+/*
+Expand
+Description:
+
+	"Expands" from the current graph node to all of the adjacent nodes,
+	leaving the current node at the time it was reached.
+	Returns a slice of PlanningNodes that represent the expanded nodes.
+*/
+func (pn *PlanningNode) Expand(heuristic func(*PlanningNode) float64) []*PlanningNode {
+	// Create a slice to hold the expanded nodes
+	var expandedNodes []*PlanningNode
+	neighboringNodes := pn.Graph.From(pn.CurrentGraphNode.ID())
+	for neighboringNodes.Next() {
+		// Get current node and the time needed to reach it
+		node := neighboringNodes.Node()
+		travelTime, ok := pn.Graph.TravelTime(pn.CurrentGraphNode.ID(), node.ID(), pn.Arrival)
+		if !ok {
+			continue
+		}
+
+		// Create a new planning node for the expanded node
+		expandedNode := &PlanningNode{
+			Graph:            pn.Graph,
+			CurrentGraphNode: node,
+			PreviousInPlan:   pn,
+			Departure:        pn.Departure,
+			Arrival:          pn.Arrival + travelTime,
+		}
+		if heuristic != nil {
+			expandedNode.HeuristicCost = heuristic(expandedNode)
+		}
+
+		expandedNodes = append(expandedNodes, expandedNode)
+	}
+
+	return expandedNodes
+}
@@ -0,0 +1,35 @@
+package planning
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+time_dependent.go
+Description:
+
+	Defines the interface for graphs whose edge costs depend on
+	when the edge is entered (e.g., travel times during rush hour).
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+TimeDependent
+Description:
+
+	A graph whose edges take a time to traverse that depends on the time
+	of departure. TravelTime returns the time needed to move from the node
+	with ID uid to the node with ID vid when leaving uid at time departure,
+	and false if there is no such edge.
+
+	Travel times must be non-negative and satisfy the FIFO property:
+	leaving later never means arriving earlier (i.e., departure + TravelTime
+	never decreases as departure grows). Planners rely on it for optimality.
+*/
+type TimeDependent interface {
+	graph.Graph
+	TravelTime(uid, vid int64, departure float64) (float64, bool)
+}
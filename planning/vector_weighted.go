@@ -0,0 +1,31 @@
+package planning
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+/*
+vector_weighted.go
+Description:
+
+	Defines the interface for graphs whose edges carry a vector of
+	costs (e.g., distance, energy and risk) instead of a single weight.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+VectorWeighted
+Description:
+
+	A graph whose edges carry a vector of costs.
+	CostVector returns the costs of the edge from the node with ID xid
+	to the node with ID yid, and false if there is no such edge.
+	Every edge in the graph must return a vector of the same length.
+*/
+type VectorWeighted interface {
+	graph.Graph
+	CostVector(xid, yid int64) ([]float64, bool)
+}
@@ -0,0 +1,260 @@
+package planningHeap
+
+import "container/heap"
+
+/*
+indexed_planning_heap.go
+Description:
+
+	Defines a priority queue of planning nodes that remembers where
+	each graph node lives in the heap. This lets planners update the
+	priority of a node in place instead of pushing duplicates.
+*/
+
+// ================
+// Type Definitions
+// ================
+
+/*
+IndexedPlanningNode
+Description:
+
+	A PlanningNode that can report the ID of the graph node it represents.
+	The indexed heap stores at most one planning node per graph node ID.
+*/
+type IndexedPlanningNode interface {
+	PlanningNode
+	NodeID() int64
+}
+
+/*
+IndexedPlanningHeap
+Description:
+
+	A min-heap of IndexedPlanningNodes (ordered by Cost(), then by
+	TieBreakCost() for TieBreakingPlanningNodes) with a
+	lookup table from graph node ID to heap position.
+*/
+type IndexedPlanningHeap struct {
+	items indexedItems
+}
+
+/*
+indexedItems
+Description:
+
+	The container/heap implementation behind the IndexedPlanningHeap.
+*/
+type indexedItems struct {
+	nodes     []IndexedPlanningNode
+	positions map[int64]int
+}
+
+// =========
+// Functions
+// =========
+
+/*
+NewIndexedPlanningHeap
+Description:
+
+	Creates an empty IndexedPlanningHeap.
+*/
+func NewIndexedPlanningHeap() *IndexedPlanningHeap {
+	return &IndexedPlanningHeap{
+		items: indexedItems{
+			positions: make(map[int64]int),
+		},
+	}
+}
+
+// =======
+// Methods
+// =======
+
+/*
+Len
+Description:
+
+	Returns the number of planning nodes in the heap.
+*/
+func (iph *IndexedPlanningHeap) Len() int {
+	return iph.items.Len()
+}
+
+/*
+Contains
+Description:
+
+	Returns true if the heap holds a planning node for the graph node with the given ID.
+*/
+func (iph *IndexedPlanningHeap) Contains(id int64) bool {
+	_, ok := iph.items.positions[id]
+	return ok
+}
+
+/*
+Get
+Description:
+
+	Returns the planning node stored for the graph node with the given ID, if any.
+*/
+func (iph *IndexedPlanningHeap) Get(id int64) (IndexedPlanningNode, bool) {
+	position, ok := iph.items.positions[id]
+	if !ok {
+		return nil, false
+	}
+
+	return iph.items.nodes[position], true
+}
+
+/*
+Min
+Description:
+
+	Returns the planning node with the lowest cost without removing it.
+	Returns nil if the heap is empty.
+*/
+func (iph *IndexedPlanningHeap) Min() IndexedPlanningNode {
+	if iph.Len() == 0 {
+		return nil
+	}
+
+	return iph.items.nodes[0]
+}
+
+/*
+Push
+Description:
+
+	Adds a planning node to the heap. If the heap already holds a planning
+	node for the same graph node, that entry is replaced (see Update).
+*/
+func (iph *IndexedPlanningHeap) Push(pn IndexedPlanningNode) {
+	if iph.Contains(pn.NodeID()) {
+		iph.Update(pn)
+		return
+	}
+
+	heap.Push(&iph.items, pn)
+}
+
+/*
+PopMin
+Description:
+
+	Removes and returns the planning node with the lowest cost.
+	Returns nil if the heap is empty.
+*/
+func (iph *IndexedPlanningHeap) PopMin() IndexedPlanningNode {
+	if iph.Len() == 0 {
+		return nil
+	}
+
+	return heap.Pop(&iph.items).(IndexedPlanningNode)
+}
+
+/*
+DecreaseKey
+Description:
+
+	Replaces the entry for pn's graph node with pn, but only if pn
+	is cheaper than the current entry. Returns true if the entry was replaced.
+	Returns false (and does nothing) if the graph node is not in the heap.
+*/
+func (iph *IndexedPlanningHeap) DecreaseKey(pn IndexedPlanningNode) bool {
+	// Input Processing
+	position, ok := iph.items.positions[pn.NodeID()]
+	if !ok {
+		return false
+	}
+
+	// Algorithm
+	if pn.Cost() >= iph.items.nodes[position].Cost() {
+		return false
+	}
+
+	iph.items.nodes[position] = pn
+	heap.Fix(&iph.items, position)
+	return true
+}
+
+/*
+Update
+Description:
+
+	Replaces the entry for pn's graph node with pn, regardless of whether
+	the cost went up or down. If the graph node is not in the heap, pn is pushed.
+*/
+func (iph *IndexedPlanningHeap) Update(pn IndexedPlanningNode) {
+	// Input Processing
+	position, ok := iph.items.positions[pn.NodeID()]
+	if !ok {
+		heap.Push(&iph.items, pn)
+		return
+	}
+
+	// Algorithm
+	iph.items.nodes[position] = pn
+	heap.Fix(&iph.items, position)
+}
+
+/*
+Remove
+Description:
+
+	Removes and returns the planning node stored for the graph node with
+	the given ID. Returns nil if the graph node is not in the heap.
+*/
+func (iph *IndexedPlanningHeap) Remove(id int64) IndexedPlanningNode {
+	// Input Processing
+	position, ok := iph.items.positions[id]
+	if !ok {
+		return nil
+	}
+
+	// Algorithm
+	return heap.Remove(&iph.items, position).(IndexedPlanningNode)
+}
+
+// ===================
+// container/heap glue
+// ===================
+
+func (items indexedItems) Len() int { return len(items.nodes) }
+func (items indexedItems) Less(i, j int) bool {
+	//Description:
+	//	Returns true iff items.nodes[i] < items.nodes[j] in terms of the Cost
+	//	(ties are broken by the TieBreakCost, if available)
+
+	return less(items.nodes[i], items.nodes[j])
+}
+func (items indexedItems) Swap(i, j int) {
+	//Description:
+	//	Swaps the elements i and j and keeps the position table in sync.
+
+	items.nodes[i], items.nodes[j] = items.nodes[j], items.nodes[i]
+	items.positions[items.nodes[i].NodeID()] = i
+	items.positions[items.nodes[j].NodeID()] = j
+}
+
+func (items *indexedItems) Push(x any) {
+	//Description:
+	//	Appends a planning node and records its position.
+
+	item := x.(IndexedPlanningNode)
+	items.positions[item.NodeID()] = len(items.nodes)
+	items.nodes = append(items.nodes, item)
+}
+
+func (items *indexedItems) Pop() any {
+	//Description:
+	//	Removes the last planning node and forgets its position.
+
+	n := len(items.nodes)
+	item := items.nodes[n-1]
+	items.nodes[n-1] = nil // avoid memory leak
+	items.nodes = items.nodes[0 : n-1]
+	delete(items.positions, item.NodeID())
+	return item
+}
@@ -7,7 +7,7 @@ func (ph PlanningHeap) Less(i, j int) bool {
 	//Description:
 	//	Returns true iff ph[i] < ph[j] in terms of the Cost
 
-	return ph[i].Cost() < ph[j].Cost()
+	return less(ph[i], ph[j])
 }
 func (ph PlanningHeap) Swap(i, j int) {
 	//Description:
@@ -3,3 +3,37 @@ package planningHeap
 type PlanningNode interface {
 	Cost() float64
 }
+
+/*
+TieBreakingPlanningNode
+Description:
+
+	A PlanningNode with a second cost that orders nodes whose Cost()
+	is equal (e.g., the two-part keys used by D* Lite and LPA*).
+	The heaps compare TieBreakCost() only when both nodes implement it.
+*/
+type TieBreakingPlanningNode interface {
+	PlanningNode
+	TieBreakCost() float64
+}
+
+/*
+less
+Description:
+
+	Returns true iff a < b in terms of the Cost, using the
+	TieBreakCost to order nodes with equal costs.
+*/
+func less(a, b PlanningNode) bool {
+	if a.Cost() != b.Cost() {
+		return a.Cost() < b.Cost()
+	}
+
+	tbA, okA := a.(TieBreakingPlanningNode)
+	tbB, okB := b.(TieBreakingPlanningNode)
+	if !okA || !okB {
+		return false
+	}
+
+	return tbA.TieBreakCost() < tbB.TieBreakCost()
+}
@@ -0,0 +1,79 @@
+package fixtures
+
+import (
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"github.com/GraphPathPlanning.go/planning"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+grid.go
+Description:
+
+	Defines the grids and plan checks shared by the tests of the grid planners.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+RandomGrid
+Description:
+
+	Creates a width x height grid where each cell is blocked with probability density.
+	If withCosts is true, each cell also gets a random traversal cost between 1 and 3.
+*/
+func RandomGrid(rng *rand.Rand, width, height int, density float64, withCosts bool) *grid_graph.Grid {
+	g := grid_graph.New(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if rng.Float64() < density {
+				g.Block(x, y)
+			}
+			if withCosts {
+				_ = g.SetCost(1.0+2.0*rng.Float64(), x, y)
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+CheckPlan
+Description:
+
+	Fails the test if the plan p does not go from start to end, if segment
+	reports that one of its steps cannot be taken, or if its edge costs do
+	not match the costs given by segment and add up to its total cost.
+	Pass g.Weight to check steps between neighboring cells, or
+	g.LineOfSight to check straight segments.
+*/
+func CheckPlan(t *testing.T, p *planning.Plan, start, end int64, segment func(from, to int64) (float64, bool)) {
+	t.Helper()
+
+	ids := p.NodeIDs()
+	if ids[0] != start || ids[len(ids)-1] != end {
+		t.Errorf("expected plan to go from %v to %v; received %v", start, end, ids)
+	}
+
+	total := 0.0
+	for idx := 0; idx+1 < len(ids); idx++ {
+		cost, ok := segment(ids[idx], ids[idx+1])
+		if !ok {
+			t.Fatalf("plan %v cannot step from %v to %v", ids, ids[idx], ids[idx+1])
+		}
+
+		if math.Abs(cost-p.EdgeCosts[idx]) > 1e-9 {
+			t.Errorf("expected segment %v of plan %v to cost %v; received %v", idx, ids, cost, p.EdgeCosts[idx])
+		}
+		total += p.EdgeCosts[idx]
+	}
+
+	if math.Abs(total-p.CostToGo) > 1e-9 {
+		t.Errorf("expected edge costs to add up to %v; received %v", p.CostToGo, total)
+	}
+}
@@ -0,0 +1,103 @@
+package fixtures
+
+import (
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"testing"
+)
+
+/*
+lattice.go
+Description:
+
+	Defines the lattice graphs, heuristic and plan checks shared by
+	the tests of the incremental planners.
+*/
+
+// =========
+// Functions
+// =========
+
+/*
+LatticeGraph
+Description:
+
+	Creates a square lattice graph with n nodes on each side,
+	where every node is connected to its horizontal and vertical
+	neighbors. Node row*n+col is at position (col, row).
+*/
+func LatticeGraph(n int) *position_graph.PositionGraph {
+	// Constants
+	g := position_graph.New()
+
+	// Algorithm
+	nodes := make([]position_graph.Node, n*n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			nodes[row*n+col] = g.AddNodeAt(
+				mat.NewVecDense(2, []float64{float64(col), float64(row)}),
+			)
+		}
+	}
+
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			if col+1 < n {
+				g.AddEdgeBetween(nodes[row*n+col], nodes[row*n+col+1])
+			}
+			if row+1 < n {
+				g.AddEdgeBetween(nodes[row*n+col], nodes[(row+1)*n+col])
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+Euclidean
+Description:
+
+	A heuristic that returns the straight-line distance between two position graph nodes.
+*/
+func Euclidean(from, to graph.Node) float64 {
+	var diff mat.VecDense
+	diff.SubVec(from.(*position_graph.Node).Position, to.(*position_graph.Node).Position)
+	return mat.Norm(&diff, 2)
+}
+
+/*
+CheckAgainstDjikstra
+Description:
+
+	Fails the test if the plan p does not connect start to end through
+	edges of the reference graph ref, or if it does not cost the same as
+	the plan found by Djikstra's algorithm on ref.
+*/
+func CheckAgainstDjikstra(t *testing.T, ref graph.Weighted, p *planning.Plan, start, end int64) {
+	t.Helper()
+
+	expected, err := djikstra.FindPlan(ref, start, end)
+	if err != nil {
+		t.Fatalf("there was a problem finding the reference plan: %v", err)
+	}
+
+	if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+		t.Errorf("expected plan to cost %v; received %v", expected.CostToGo, p.CostToGo)
+	}
+
+	ids := p.NodeIDs()
+	if ids[0] != start || ids[len(ids)-1] != end {
+		t.Errorf("expected plan to go from %v to %v; received %v", start, end, ids)
+	}
+
+	for idx := 0; idx+1 < len(ids); idx++ {
+		if _, ok := ref.Weight(ids[idx], ids[idx+1]); !ok {
+			t.Errorf("plan %v uses an edge that does not exist", ids)
+		}
+	}
+}
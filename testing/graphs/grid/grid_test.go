@@ -0,0 +1,508 @@
+package grid_graph_test
+
+import (
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"slices"
+	"testing"
+)
+
+/*
+grid_test.go
+Description:
+
+	Tests the Grid graph.
+*/
+
+/*
+neighborIDs
+Description:
+
+	Collects the IDs of the nodes in the iterator nodes.
+*/
+func neighborIDs(nodes graph.Nodes) []int64 {
+	var ids []int64
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+/*
+TestGrid_ID1
+Description:
+
+	Tests that ID and Cell convert between cells and node IDs.
+*/
+func TestGrid_ID1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(4, 3)
+
+	// Test
+	if id := g.ID(1, 2); id != 9 {
+		t.Errorf("expected cell {1, 2} to have ID 9; received %v", id)
+	}
+
+	if cell := g.Cell(9); !slices.Equal(cell, []int{1, 2}) {
+		t.Errorf("expected ID 9 to be cell {1, 2}; received %v", cell)
+	}
+
+	if id := g.ID(4, 0); id != -1 {
+		t.Errorf("expected a cell outside the grid to have ID -1; received %v", id)
+	}
+
+	if cell := g.Cell(12); cell != nil {
+		t.Errorf("expected an ID outside the grid to have no cell; received %v", cell)
+	}
+}
+
+/*
+TestGrid_Nodes1
+Description:
+
+	Tests that Nodes only lists the free cells.
+*/
+func TestGrid_Nodes1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(3, 3)
+	g.Block(1, 1)
+	g.Block(0, 2)
+	g.Block(0, 2)
+
+	// Test
+	nodes := g.Nodes()
+	if nodes.Len() != 7 {
+		t.Errorf("expected 7 free cells; received %v", nodes.Len())
+	}
+
+	ids := neighborIDs(nodes)
+	if !slices.Equal(ids, []int64{0, 1, 2, 3, 5, 7, 8}) {
+		t.Errorf("expected the free cells to be listed; received %v", ids)
+	}
+
+	if g.Node(4) != nil {
+		t.Errorf("expected a blocked cell to have no node")
+	}
+
+	g.Unblock(1, 1)
+	if g.Nodes().Len() != 8 || g.Node(4) == nil {
+		t.Errorf("expected the unblocked cell to be a node again")
+	}
+}
+
+/*
+TestGrid_From1
+Description:
+
+	Tests the neighbors of a cell next to an obstacle under each diagonal policy.
+*/
+func TestGrid_From1(t *testing.T) {
+	// Setup: the cell {1, 1} with the cells {2, 1} and {1, 2} blocked
+	//   . . .
+	//   . o #
+	//   . # .
+	testCases := []struct {
+		policy   grid_graph.DiagonalPolicy
+		expected []int64
+	}{
+		{grid_graph.NoCornerCutting, []int64{0, 1, 3}},
+		{grid_graph.AllowCornerCutting, []int64{0, 1, 2, 3, 6}},
+		{grid_graph.AllowSqueezing, []int64{0, 1, 2, 3, 6, 8}},
+	}
+
+	// Test
+	for _, tc := range testCases {
+		g := grid_graph.New(3, 3)
+		g.Diagonal = tc.policy
+		g.Block(2, 1)
+		g.Block(1, 2)
+
+		ids := neighborIDs(g.From(g.ID(1, 1)))
+		if !slices.Equal(ids, tc.expected) {
+			t.Errorf("policy %v: expected neighbors %v; received %v", tc.policy, tc.expected, ids)
+		}
+	}
+
+	// Test: corner cutting around a single obstacle
+	g := grid_graph.New(3, 3)
+	g.Block(2, 1)
+	if g.HasEdgeBetween(g.ID(1, 1), g.ID(2, 2)) {
+		t.Errorf("expected NoCornerCutting to forbid cutting the corner of {2, 1}")
+	}
+
+	g.Diagonal = grid_graph.AllowCornerCutting
+	if !g.HasEdgeBetween(g.ID(1, 1), g.ID(2, 2)) {
+		t.Errorf("expected AllowCornerCutting to allow cutting the corner of {2, 1}")
+	}
+}
+
+/*
+TestGrid_Weight1
+Description:
+
+	Tests the weights of orthogonal, diagonal and missing edges.
+*/
+func TestGrid_Weight1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(3, 3)
+
+	// Test
+	if w, ok := g.Weight(g.ID(0, 0), g.ID(1, 0)); !ok || w != 1.0 {
+		t.Errorf("expected an orthogonal step to cost 1; received %v, %v", w, ok)
+	}
+
+	if w, ok := g.Weight(g.ID(0, 0), g.ID(1, 1)); !ok || w != math.Sqrt2 {
+		t.Errorf("expected a diagonal step to cost sqrt(2); received %v, %v", w, ok)
+	}
+
+	if _, ok := g.Weight(g.ID(0, 0), g.ID(2, 0)); ok {
+		t.Errorf("expected cells two steps apart to have no edge")
+	}
+
+	if _, ok := g.Weight(g.ID(2, 0), g.ID(0, 1)); ok {
+		t.Errorf("expected cells on opposite sides of the grid to have no edge")
+	}
+
+	if e := g.WeightedEdge(g.ID(0, 0), g.ID(1, 1)); e == nil || e.From().ID() != 0 || e.To().ID() != 4 {
+		t.Errorf("expected an edge from 0 to 4; received %v", e)
+	}
+}
+
+/*
+TestGrid_ID2
+Description:
+
+	Tests that ID and Cell convert between cells and node IDs in 3D.
+*/
+func TestGrid_ID2(t *testing.T) {
+	// Setup
+	g := grid_graph.New3D(4, 3, 2)
+
+	// Test
+	if id := g.ID(1, 2, 1); id != 21 {
+		t.Errorf("expected cell {1, 2, 1} to have ID 21; received %v", id)
+	}
+
+	if cell := g.Cell(21); !slices.Equal(cell, []int{1, 2, 1}) {
+		t.Errorf("expected ID 21 to be cell {1, 2, 1}; received %v", cell)
+	}
+
+	if id := g.ID(1, 2); id != -1 {
+		t.Errorf("expected a 2D cell to be outside a 3D grid; received ID %v", id)
+	}
+
+	if g.Nodes().Len() != 24 || g.Depth() != 2 || g.Dimensions() != 3 {
+		t.Errorf("expected a 4 x 3 x 2 grid; received shape %v", g.Shape())
+	}
+}
+
+/*
+TestGrid_SetConnectivity1
+Description:
+
+	Tests the number of neighbors of an interior cell for every connectivity,
+	and that connectivities that do not match the grid are rejected.
+*/
+func TestGrid_SetConnectivity1(t *testing.T) {
+	// Setup
+	testCases := []struct {
+		grid         *grid_graph.Grid
+		connectivity grid_graph.Connectivity
+		cell         []int
+	}{
+		{grid_graph.New(3, 3), grid_graph.Connect4, []int{1, 1}},
+		{grid_graph.New(3, 3), grid_graph.Connect8, []int{1, 1}},
+		{grid_graph.New3D(3, 3, 3), grid_graph.Connect6, []int{1, 1, 1}},
+		{grid_graph.New3D(3, 3, 3), grid_graph.Connect26, []int{1, 1, 1}},
+	}
+
+	// Test
+	for _, tc := range testCases {
+		if err := tc.grid.SetConnectivity(tc.connectivity); err != nil {
+			t.Fatalf("there was a problem setting the connectivity: %v", err)
+		}
+
+		nNeighbors := tc.grid.From(tc.grid.ID(tc.cell...)).Len()
+		if nNeighbors != int(tc.connectivity) {
+			t.Errorf("expected %v neighbors; received %v", tc.connectivity, nNeighbors)
+		}
+	}
+
+	err := grid_graph.New(3, 3).SetConnectivity(grid_graph.Connect26)
+	if err != (grid_graph.InvalidConnectivityError{Connectivity: grid_graph.Connect26, Dimensions: 2}) {
+		t.Errorf("expected an InvalidConnectivityError; received %v", err)
+	}
+
+	g := grid_graph.New(3, 3)
+	_ = g.SetConnectivity(grid_graph.Connect4)
+	if g.HasEdgeBetween(g.ID(0, 0), g.ID(1, 1)) {
+		t.Errorf("expected a 4-connected grid to have no diagonal edges")
+	}
+}
+
+/*
+TestGrid_CanMove1
+Description:
+
+	Tests the diagonal policies for a 3D step along all three axes.
+*/
+func TestGrid_CanMove1(t *testing.T) {
+	// Setup: block every corner of the step from {0, 0, 0} to {1, 1, 1} except {1, 0, 0}
+	g := grid_graph.New3D(2, 2, 2)
+	g.Block(0, 1, 0)
+	g.Block(0, 0, 1)
+	g.Block(1, 1, 0)
+	g.Block(1, 0, 1)
+	g.Block(0, 1, 1)
+
+	from, step := []int{0, 0, 0}, []int{1, 1, 1}
+
+	// Test
+	g.Diagonal = grid_graph.NoCornerCutting
+	if g.CanMove(from, step) {
+		t.Errorf("expected NoCornerCutting to forbid the step")
+	}
+
+	g.Diagonal = grid_graph.AllowCornerCutting
+	if !g.CanMove(from, step) {
+		t.Errorf("expected AllowCornerCutting to allow the step")
+	}
+
+	g.Block(1, 0, 0)
+	if g.CanMove(from, step) {
+		t.Errorf("expected AllowCornerCutting to forbid the step once every corner is blocked")
+	}
+
+	g.Diagonal = grid_graph.AllowSqueezing
+	if !g.CanMove(from, step) {
+		t.Errorf("expected AllowSqueezing to allow the step")
+	}
+}
+
+/*
+TestGrid_FromOccupancy1
+Description:
+
+	Tests creating 2D and 3D grids from occupancy arrays.
+*/
+func TestGrid_FromOccupancy1(t *testing.T) {
+	// Setup
+	occupancy := [][]bool{
+		{false, true, false},
+		{false, false, false},
+	}
+
+	// Test
+	g, err := grid_graph.FromOccupancy(occupancy)
+	if err != nil {
+		t.Fatalf("there was a problem creating the grid: %v", err)
+	}
+
+	if g.Width() != 3 || g.Height() != 2 || !g.IsBlocked(1, 0) || g.Nodes().Len() != 5 {
+		t.Errorf("expected a 3 x 2 grid with cell {1, 0} blocked; received shape %v", g.Shape())
+	}
+
+	g3, err := grid_graph.FromOccupancy3D([][][]bool{occupancy, occupancy})
+	if err != nil {
+		t.Fatalf("there was a problem creating the grid: %v", err)
+	}
+
+	if !g3.IsBlocked(1, 0, 1) || g3.IsBlocked(0, 0, 1) || g3.Nodes().Len() != 10 {
+		t.Errorf("expected cell {1, 0, 1} to be the only blocked cell of its layer")
+	}
+
+	_, err = grid_graph.FromOccupancy([][]bool{{false, false}, {false}})
+	if _, ok := err.(grid_graph.OccupancyShapeError); !ok {
+		t.Errorf("expected an OccupancyShapeError; received %v", err)
+	}
+}
+
+/*
+TestGrid_SetCost1
+Description:
+
+	Tests that edge weights use the traversal costs of both cells and the cell size.
+*/
+func TestGrid_SetCost1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(3, 3)
+	if err := g.SetResolution(0.5); err != nil {
+		t.Fatalf("there was a problem setting the resolution: %v", err)
+	}
+	if err := g.SetCost(3.0, 1, 0); err != nil {
+		t.Fatalf("there was a problem setting the cost: %v", err)
+	}
+
+	// Test
+	if w, _ := g.Weight(g.ID(0, 0), g.ID(1, 0)); w != 0.5*(1.0+3.0)/2.0 {
+		t.Errorf("expected the weight to be 1; received %v", w)
+	}
+
+	if w, _ := g.Weight(g.ID(0, 0), g.ID(0, 1)); w != 0.5 {
+		t.Errorf("expected the weight to be 0.5; received %v", w)
+	}
+
+	if _, uniform := g.UniformCost(); uniform {
+		t.Errorf("expected the grid to have non-uniform costs")
+	}
+
+	if err := g.SetCost(0.0, 1, 1); err != (grid_graph.InvalidCostError{Cost: 0.0}) {
+		t.Errorf("expected an InvalidCostError; received %v", err)
+	}
+
+	g.Block(1, 0)
+	if cost, uniform := g.UniformCost(); !uniform || cost != 1.0 {
+		t.Errorf("expected the free cells to have uniform costs; received %v, %v", cost, uniform)
+	}
+}
+
+/*
+TestGrid_Position1
+Description:
+
+	Tests the conversion between cells and positions.
+*/
+func TestGrid_Position1(t *testing.T) {
+	// Setup
+	g := grid_graph.New3D(10, 10, 10)
+	if err := g.SetOrigin(mat.NewVecDense(3, []float64{-1.0, 2.0, 0.0})); err != nil {
+		t.Fatalf("there was a problem setting the origin: %v", err)
+	}
+	if err := g.SetResolution(0.25); err != nil {
+		t.Fatalf("there was a problem setting the resolution: %v", err)
+	}
+
+	// Test
+	position := g.Position(2, 0, 4)
+	expected := mat.NewVecDense(3, []float64{-0.375, 2.125, 1.125})
+	if !mat.EqualApprox(position, expected, 1e-12) {
+		t.Errorf("expected position %v; received %v", mat.Formatted(expected.T()), mat.Formatted(position.T()))
+	}
+
+	cell, ok := g.CellAt(position)
+	if !ok || !slices.Equal(cell, []int{2, 0, 4}) {
+		t.Errorf("expected the position to be in cell {2, 0, 4}; received %v", cell)
+	}
+
+	if _, ok := g.CellAt(mat.NewVecDense(3, []float64{-1.5, 2.0, 0.0})); ok {
+		t.Errorf("expected a position outside the grid to have no cell")
+	}
+}
+
+/*
+TestGrid_Position2
+Description:
+
+	Tests that the grid rejects origins of the wrong length and cell sizes
+	that are not positive and finite, and keeps its previous settings.
+*/
+func TestGrid_Position2(t *testing.T) {
+	// Setup
+	g := grid_graph.New3D(4, 4, 4)
+
+	// Test
+	if err := g.SetOrigin(mat.NewVecDense(2, []float64{1.0, 1.0})); err != (grid_graph.InvalidOriginError{Length: 2, Dimensions: 3}) {
+		t.Errorf("expected an InvalidOriginError; received %v", err)
+	}
+
+	for _, resolution := range []float64{0.0, -0.5, math.Inf(1), math.NaN()} {
+		if err := g.SetResolution(resolution); err == nil {
+			t.Errorf("expected an InvalidResolutionError for the resolution %v", resolution)
+		}
+	}
+
+	if g.CellSize() != 1.0 || !mat.Equal(g.Origin(), mat.NewVecDense(3, nil)) {
+		t.Errorf("expected the default cell size and origin; received %v, %v", g.CellSize(), mat.Formatted(g.Origin().T()))
+	}
+
+	expected := mat.NewVecDense(3, []float64{0.5, 1.5, 2.5})
+	if position := g.Position(0, 1, 2); !mat.Equal(position, expected) {
+		t.Errorf("expected position %v; received %v", mat.Formatted(expected.T()), mat.Formatted(position.T()))
+	}
+
+	if w, _ := g.Weight(g.ID(0, 0, 0), g.ID(1, 0, 0)); w != 1.0 {
+		t.Errorf("expected the weight to be 1; received %v", w)
+	}
+}
+
+/*
+TestGrid_Plan1
+Description:
+
+	Tests that a planner finds a plan through a 3D grid with a wall.
+*/
+func TestGrid_Plan1(t *testing.T) {
+	// Setup: a wall at z = 2 with a single hole at {4, 4, 2}
+	g := grid_graph.New3D(5, 5, 5)
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 5; y++ {
+			if x != 4 || y != 4 {
+				g.Block(x, y, 2)
+			}
+		}
+	}
+
+	// Test
+	p, err := djikstra.FindPlan(g, g.ID(0, 0, 0), g.ID(0, 0, 4))
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	// The corners of the hole are blocked, so it can only be crossed straight along z
+	expected := 2*(math.Sqrt(3)+3*math.Sqrt(2)) + 2.0
+	if math.Abs(p.CostToGo-expected) > 1e-9 {
+		t.Errorf("expected plan cost %v; received %v (plan %v)", expected, p.CostToGo, p.NodeIDs())
+	}
+}
+
+/*
+TestGrid_LineOfSight1
+Description:
+
+	Tests the straight-line checks between cells, including corners and cell costs.
+*/
+func TestGrid_LineOfSight1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(5, 5)
+	g.Block(2, 1)
+
+	// Test: a segment through a blocked cell
+	if _, ok := g.LineOfSight(g.ID(0, 0), g.ID(4, 2)); ok {
+		t.Errorf("expected the segment through {2, 1} to be blocked")
+	}
+
+	cost, ok := g.LineOfSight(g.ID(0, 0), g.ID(1, 4))
+	if !ok || math.Abs(cost-math.Sqrt(17.0)) > 1e-12 {
+		t.Errorf("expected a free segment of cost %v; received %v, %v", math.Sqrt(17.0), cost, ok)
+	}
+
+	// Test: a segment through the corner between {2, 1} and {1, 2}
+	g.Block(1, 2)
+	g.Diagonal = grid_graph.AllowCornerCutting
+	if _, ok := g.LineOfSight(g.ID(0, 0), g.ID(3, 3)); ok {
+		t.Errorf("expected AllowCornerCutting to block a segment between two blocked corners")
+	}
+
+	g.Diagonal = grid_graph.AllowSqueezing
+	if _, ok := g.LineOfSight(g.ID(0, 0), g.ID(3, 3)); !ok {
+		t.Errorf("expected AllowSqueezing to allow a segment between two blocked corners")
+	}
+
+	// Test: costs are weighted by the length inside each cell
+	_ = g.SetCost(3.0, 4, 4)
+	for _, target := range [][]int{{3, 4}, {3, 3}} {
+		w, _ := g.Weight(g.ID(4, 4), g.ID(target...))
+		if cost, _ := g.LineOfSight(g.ID(4, 4), g.ID(target...)); math.Abs(cost-w) > 1e-12 {
+			t.Errorf("expected the segment to {%v} to cost the same as the edge (%v); received %v", target, w, cost)
+		}
+	}
+
+	if cost, _ := g.LineOfSight(g.ID(4, 4), g.ID(4, 2)); math.Abs(cost-(1.5+0.5*3.0)) > 1e-12 {
+		t.Errorf("expected the segment to cost 3; received %v", cost)
+	}
+}
@@ -0,0 +1,179 @@
+package position_graph_test
+
+import (
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"gonum.org/v1/gonum/mat"
+	"testing"
+)
+
+/*
+directed_graph_test.go
+Description:
+
+	Tests the DirectedPositionGraph object.
+*/
+
+/*
+CreateTestGraph_ForDirectedPositionGraph1
+Description:
+
+	Creates a simple directed test graph with four nodes:
+	0 -> 1, 1 -> 2, 2 -> 0 (a one-way loop) and 2 <-> 3.
+*/
+func CreateTestGraph_ForDirectedPositionGraph1() *position_graph.DirectedPositionGraph {
+	// Constants
+	g := position_graph.NewDirected()
+
+	// Algorithm
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 1.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 1.0}))
+
+	g.AddEdgeBetween(n0, n1)
+	g.AddEdgeBetween(n1, n2)
+	g.AddEdgeBetween(n2, n0)
+	g.AddEdgeBetween(n2, n3)
+	g.AddEdgeBetween(n3, n2)
+
+	return g
+}
+
+/*
+TestDirectedPositionGraph_From1
+Description:
+
+	Tests that From returns successors and To returns predecessors.
+*/
+func TestDirectedPositionGraph_From1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForDirectedPositionGraph1()
+
+	// Test
+	if g.From(0).Len() != 1 {
+		t.Errorf("expected node 0 to have 1 successor; received %v", g.From(0).Len())
+	}
+
+	if g.To(0).Len() != 1 {
+		t.Errorf("expected node 0 to have 1 predecessor; received %v", g.To(0).Len())
+	}
+
+	if g.From(2).Len() != 2 {
+		t.Errorf("expected node 2 to have 2 successors; received %v", g.From(2).Len())
+	}
+
+	if _, err := g.Neighbors(10); err != (position_graph.NodeNotFoundError{ID: 10}) {
+		t.Errorf("expected a NodeNotFoundError; received %v", err)
+	}
+}
+
+/*
+TestDirectedPositionGraph_HasEdgeFromTo1
+Description:
+
+	Tests that edges can only be found in the direction they were added.
+*/
+func TestDirectedPositionGraph_HasEdgeFromTo1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForDirectedPositionGraph1()
+
+	// Test
+	if !g.HasEdgeFromTo(0, 1) {
+		t.Errorf("expected an edge from 0 to 1")
+	}
+
+	if g.HasEdgeFromTo(1, 0) {
+		t.Errorf("did not expect an edge from 1 to 0")
+	}
+
+	if !g.HasEdgeBetween(1, 0) {
+		t.Errorf("expected an edge between 1 and 0 (in some direction)")
+	}
+
+	if g.Edge(1, 0) != nil {
+		t.Errorf("did not expect Edge(1, 0) to return an edge")
+	}
+
+	if _, ok := g.Weight(1, 0); ok {
+		t.Errorf("did not expect a weight from 1 to 0")
+	}
+
+	if wght, ok := g.Weight(0, 1); !ok || wght != 1.0 {
+		t.Errorf("expected the weight from 0 to 1 to be 1.0; received %v (%v)", wght, ok)
+	}
+}
+
+/*
+TestDirectedPositionGraph_RemoveEdge1
+Description:
+
+	Tests that removing one direction of a two-way connection
+	leaves the other direction in place.
+*/
+func TestDirectedPositionGraph_RemoveEdge1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForDirectedPositionGraph1()
+
+	// Test
+	g.RemoveEdge(2, 3)
+
+	if g.HasEdgeFromTo(2, 3) {
+		t.Errorf("expected the edge from 2 to 3 to be removed")
+	}
+
+	if !g.HasEdgeFromTo(3, 2) {
+		t.Errorf("expected the edge from 3 to 2 to remain")
+	}
+}
+
+/*
+TestDirectedPositionGraph_RemoveNode1
+Description:
+
+	Tests that removing a node removes its incoming and outgoing edges.
+*/
+func TestDirectedPositionGraph_RemoveNode1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForDirectedPositionGraph1()
+
+	// Test
+	g.RemoveNode(2)
+
+	if g.From(1).Len() != 0 {
+		t.Errorf("expected node 1 to have no successors; received %v", g.From(1).Len())
+	}
+
+	if g.To(0).Len() != 0 {
+		t.Errorf("expected node 0 to have no predecessors; received %v", g.To(0).Len())
+	}
+
+	if g.To(3).Len() != 0 {
+		t.Errorf("expected node 3 to have no predecessors; received %v", g.To(3).Len())
+	}
+}
+
+/*
+TestDirectedPositionGraph_SetEdgeCost1
+Description:
+
+	Tests that costs are attached to one direction of an edge only.
+*/
+func TestDirectedPositionGraph_SetEdgeCost1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForDirectedPositionGraph1()
+
+	// Test
+	if err := g.SetEdgeCost(2, 3, "toll", 4.0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := g.SetEdgeCost(1, 0, "toll", 4.0); err != (position_graph.EdgeNotFoundError{From: 1, To: 0}) {
+		t.Errorf("expected an EdgeNotFoundError; received %v", err)
+	}
+
+	forward, _ := g.CostVector(2, 3)
+	backward, _ := g.CostVector(3, 2)
+	if len(forward) != 2 || forward[1] != 4.0 || backward[1] != 0.0 {
+		t.Errorf("expected only the edge from 2 to 3 to carry a toll; received %v and %v", forward, backward)
+	}
+}
@@ -123,3 +123,87 @@ func TestEdge_Weight1(t *testing.T) {
 		t.Errorf("Expected 1.0, got %v", edge.Weight())
 	}
 }
+
+/*
+TestEdge_Cost1
+Description:
+
+	Tests that every edge carries the distance cost (which defaults to the
+	Euclidean distance) and that missing costs are reported as missing.
+*/
+func TestEdge_Cost1(t *testing.T) {
+	// Constants
+	g := CreateTestGraph_ForEdges1()
+	edge := g.EdgeBetween(0, 1).(*positionGraph2.PGEdge)
+
+	// Algorithm
+	distance, ok := edge.Cost(positionGraph2.DistanceCost)
+	if !ok || distance != 1.0 {
+		t.Errorf("Expected distance cost 1.0, got %v (%v)", distance, ok)
+	}
+
+	if _, ok := edge.Cost("energy"); ok {
+		t.Errorf("Expected the energy cost to be missing")
+	}
+}
+
+/*
+TestEdge_CostVector1
+Description:
+
+	Tests that the cost vector of an edge follows the order of the graph's
+	cost names and fills in zero for costs the edge does not carry.
+*/
+func TestEdge_CostVector1(t *testing.T) {
+	// Constants
+	g := positionGraph2.New()
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{3.0, 4.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{3.0, 5.0}))
+
+	e1 := g.AddEdgeWithCosts(n1, n2, map[string]float64{"time": 2.0})
+	g.AddEdgeWithCosts(n2, n3, map[string]float64{"toll": 7.0})
+
+	// Algorithm
+	names := g.CostNames()
+	expectedNames := []string{positionGraph2.DistanceCost, "time", "toll"}
+	if len(names) != len(expectedNames) {
+		t.Fatalf("Expected cost names %v, got %v", expectedNames, names)
+	}
+	for idx := range names {
+		if names[idx] != expectedNames[idx] {
+			t.Errorf("Expected cost names %v, got %v", expectedNames, names)
+		}
+	}
+
+	if !mat.Equal(
+		mat.NewVecDense(3, []float64{5.0, 2.0, 0.0}),
+		mat.NewVecDense(3, e1.CostVector()),
+	) {
+		t.Errorf("Expected cost vector [5 2 0], got %v", e1.CostVector())
+	}
+}
+
+/*
+TestEdge_WeightedCost1
+Description:
+
+	Tests the weighted scalarization of an edge's costs.
+*/
+func TestEdge_WeightedCost1(t *testing.T) {
+	// Constants
+	g := positionGraph2.New()
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{3.0, 4.0}))
+	e := g.AddEdgeWithCosts(n1, n2, map[string]float64{"time": 2.0})
+
+	// Algorithm
+	cost := e.WeightedCost(map[string]float64{
+		positionGraph2.DistanceCost: 0.5,
+		"time":                      2.0,
+		"toll":                      10.0,
+	})
+	if cost != 6.5 {
+		t.Errorf("Expected weighted cost 6.5, got %v", cost)
+	}
+}
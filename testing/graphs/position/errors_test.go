@@ -28,3 +28,34 @@ func TestErrors_NodeNotFoundError1(t *testing.T) {
 		t.Errorf("unexpected error: %v", tempError)
 	}
 }
+
+func TestErrors_EdgeNotFoundError1(t *testing.T) {
+	// Constants
+	tempError := position_graph.EdgeNotFoundError{
+		From: 1,
+		To:   2,
+	}
+
+	// Test
+	if !strings.Contains(
+		tempError.Error(),
+		fmt.Sprintf("Edge between nodes %v and %v not found", tempError.From, tempError.To),
+	) {
+		t.Errorf("unexpected error: %v", tempError)
+	}
+}
+
+func TestErrors_NodeIDCollisionError1(t *testing.T) {
+	// Constants
+	tempError := position_graph.NodeIDCollisionError{
+		ID: 1,
+	}
+
+	// Test
+	if !strings.Contains(
+		tempError.Error(),
+		fmt.Sprintf("Node with ID %v already exists", tempError.ID),
+	) {
+		t.Errorf("unexpected error: %v", tempError)
+	}
+}
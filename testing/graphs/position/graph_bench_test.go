@@ -0,0 +1,217 @@
+package position_graph_test
+
+import (
+	"fmt"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+	"testing"
+)
+
+/*
+graph_bench_test.go
+Description:
+
+	Benchmarks the neighbor iteration and edge lookup of the PositionGraph
+	on graphs with 10k to 1M edges. With adjacency maps, the time per
+	operation should not grow with the number of edges in the graph,
+	unlike the linear scan that it replaced (BenchmarkEdgeScan_EdgeBetween).
+
+	Run with:
+		go test -bench=. ./testing/graphs/position/
+*/
+
+// benchmarkEdgeCounts are the (approximate) numbers of edges in the benchmark graphs.
+var benchmarkEdgeCounts = []int{10_000, 100_000, 1_000_000}
+
+/*
+CreateLatticeGraph_ForBenchmarks
+Description:
+
+	Creates a square lattice with (approximately) nEdges edges, where
+	each node is connected to its horizontal and vertical neighbors.
+	Returns the graph and the number of nodes on each side.
+*/
+func CreateLatticeGraph_ForBenchmarks(nEdges int) (*position_graph.PositionGraph, int) {
+	// Constants
+	g := position_graph.New()
+
+	side := 2
+	for 2*side*(side-1) < nEdges {
+		side++
+	}
+
+	// Algorithm
+	nodes := make([]position_graph.Node, side*side)
+	for row := 0; row < side; row++ {
+		for col := 0; col < side; col++ {
+			nodes[row*side+col] = g.AddNodeAt(
+				mat.NewVecDense(2, []float64{float64(col), float64(row)}),
+			)
+		}
+	}
+
+	for row := 0; row < side; row++ {
+		for col := 0; col < side; col++ {
+			if col+1 < side {
+				g.AddEdgeBetween(nodes[row*side+col], nodes[row*side+col+1])
+			}
+			if row+1 < side {
+				g.AddEdgeBetween(nodes[row*side+col], nodes[(row+1)*side+col])
+			}
+		}
+	}
+
+	return g, side
+}
+
+/*
+BenchmarkPositionGraph_From
+Description:
+
+	Benchmarks iterating over the neighbors of a node.
+*/
+func BenchmarkPositionGraph_From(b *testing.B) {
+	for _, nEdges := range benchmarkEdgeCounts {
+		g, side := CreateLatticeGraph_ForBenchmarks(nEdges)
+		nNodes := int64(side * side)
+
+		b.Run(fmt.Sprintf("edges=%v", nEdges), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				neighbors := g.From(int64(i) % nNodes)
+				for neighbors.Next() {
+					_ = neighbors.Node()
+				}
+			}
+		})
+	}
+}
+
+/*
+BenchmarkPositionGraph_EdgeBetween
+Description:
+
+	Benchmarks looking up the edge between two neighboring nodes.
+*/
+func BenchmarkPositionGraph_EdgeBetween(b *testing.B) {
+	for _, nEdges := range benchmarkEdgeCounts {
+		g, side := CreateLatticeGraph_ForBenchmarks(nEdges)
+		nNodes := int64(side * side)
+
+		b.Run(fmt.Sprintf("edges=%v", nEdges), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				id := int64(i) % (nNodes - 1)
+				_ = g.EdgeBetween(id, id+1)
+			}
+		})
+	}
+}
+
+/*
+BenchmarkEdgeScan_EdgeBetween
+Description:
+
+	Benchmarks the edge lookup that the PositionGraph used before it kept
+	adjacency maps (a scan over every edge, in each direction), as a
+	baseline for BenchmarkPositionGraph_EdgeBetween. Its time per operation
+	grows with the number of edges, so the 1M edge graph is skipped.
+*/
+func BenchmarkEdgeScan_EdgeBetween(b *testing.B) {
+	for _, nEdges := range benchmarkEdgeCounts[:2] {
+		g, side := CreateLatticeGraph_ForBenchmarks(nEdges)
+		nNodes := int64(side * side)
+		edges := collectEdges(g)
+
+		b.Run(fmt.Sprintf("edges=%v", nEdges), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				id := int64(i) % (nNodes - 1)
+				_ = scanEdgeBetween(edges, id, id+1)
+			}
+		})
+	}
+}
+
+/*
+BenchmarkPositionGraph_Weight
+Description:
+
+	Benchmarks collecting the weight of an edge, as the planners do.
+*/
+func BenchmarkPositionGraph_Weight(b *testing.B) {
+	for _, nEdges := range benchmarkEdgeCounts {
+		g, side := CreateLatticeGraph_ForBenchmarks(nEdges)
+		nNodes := int64(side * side)
+
+		b.Run(fmt.Sprintf("edges=%v", nEdges), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				id := int64(i) % (nNodes - 1)
+				_, _ = g.Weight(id, id+1)
+			}
+		})
+	}
+}
+
+/*
+BenchmarkDjikstra_FindPlan
+Description:
+
+	Benchmarks planning across the diagonal of the lattice, which
+	expands nearly every node in the graph.
+*/
+func BenchmarkDjikstra_FindPlan(b *testing.B) {
+	for _, nEdges := range benchmarkEdgeCounts[:2] {
+		g, side := CreateLatticeGraph_ForBenchmarks(nEdges)
+		goal := int64(side*side - 1)
+
+		b.Run(fmt.Sprintf("edges=%v", nEdges), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := djikstra.FindPlan(g, 0, goal); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+/*
+collectEdges
+Description:
+
+	Returns every edge of the graph g, keyed by an ID, like the
+	edge map that the old lookup scanned.
+*/
+func collectEdges(g *position_graph.PositionGraph) map[int64]graph.Edge {
+	edges := make(map[int64]graph.Edge)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		from := nodes.Node().ID()
+		neighbors := g.From(from)
+		for neighbors.Next() {
+			if to := neighbors.Node().ID(); from < to {
+				edges[int64(len(edges))] = g.Edge(from, to)
+			}
+		}
+	}
+
+	return edges
+}
+
+/*
+scanEdgeBetween
+Description:
+
+	The old edge lookup: scans edges for an edge from the node with
+	ID from to the node with ID to, and then for the reversed edge.
+*/
+func scanEdgeBetween(edges map[int64]graph.Edge, from, to int64) graph.Edge {
+	for _, pair := range [][2]int64{{from, to}, {to, from}} {
+		for _, e := range edges {
+			if e.From().ID() == pair[0] && e.To().ID() == pair[1] {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
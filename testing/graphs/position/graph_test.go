@@ -56,7 +56,7 @@ func CreateTestGraph_ForPositionGraph1() *position_graph.PositionGraph {
 TestPositionGraph_Node1
 Description:
 
-	Tests that the Node method properly panics
+	Tests that the Node method returns nil (as graph.Graph requires)
 	if asked for a node that does not exist.
 */
 func TestPositionGraph_Node1(t *testing.T) {
@@ -64,13 +64,9 @@ func TestPositionGraph_Node1(t *testing.T) {
 	g := CreateTestGraph_ForPositionGraph1()
 
 	// Algorithm
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("The code did not panic")
-		}
-	}()
-
-	g.Node(100)
+	if n := g.Node(100); n != nil {
+		t.Errorf("expected no node with ID 100; received %v", n)
+	}
 }
 
 /*
@@ -353,3 +349,341 @@ func TestPositionGraph_RemoveEdge1(t *testing.T) {
 		)
 	}
 }
+
+/*
+TestPositionGraph_SetEdgeCost1
+Description:
+
+	Tests that SetEdgeCost attaches a named cost to an existing edge
+	(in either direction) and can override the distance cost.
+*/
+func TestPositionGraph_SetEdgeCost1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test
+	if err := g.SetEdgeCost(1, 0, "energy", 3.0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := g.SetEdgeCost(0, 1, position_graph.DistanceCost, 10.0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	costs, ok := g.CostVector(0, 1)
+	if !ok {
+		t.Fatalf("expected a cost vector for the edge between 0 and 1")
+	}
+
+	if !mat.Equal(
+		mat.NewVecDense(2, []float64{10.0, 3.0}),
+		mat.NewVecDense(2, costs),
+	) {
+		t.Errorf("expected cost vector [10 3]; received %v", costs)
+	}
+
+	if wght, _ := g.Weight(0, 1); wght != 10.0 {
+		t.Errorf("expected the weight to follow the distance cost; received %v", wght)
+	}
+}
+
+/*
+TestPositionGraph_SetEdgeCost2
+Description:
+
+	Tests that SetEdgeCost returns an EdgeNotFoundError when there
+	is no edge between the two nodes.
+*/
+func TestPositionGraph_SetEdgeCost2(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test
+	err := g.SetEdgeCost(0, 4, "energy", 3.0)
+	expectedError := position_graph.EdgeNotFoundError{From: 0, To: 4}
+	if err != expectedError {
+		t.Errorf("expected error \"%v\"; received \"%v\"", expectedError, err)
+	}
+
+	if _, ok := g.CostVector(0, 4); ok {
+		t.Errorf("expected no cost vector between nodes that are not connected")
+	}
+}
+
+/*
+TestPositionGraph_RemoveNode1
+Description:
+
+	Tests that when we remove a node (node 1), all of its edges are
+	removed too, so its former neighbors no longer reach it.
+*/
+func TestPositionGraph_RemoveNode1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test remove method
+	g.RemoveNode(1)
+
+	if g.HasEdgeBetween(0, 1) || g.HasEdgeBetween(3, 1) {
+		t.Errorf("expected all edges of node 1 to be removed")
+	}
+
+	if g.From(0).Len() != 1 {
+		t.Errorf("expected node 0 to have 1 neighbor after removal; received %v", g.From(0).Len())
+	}
+
+	if g.From(3).Len() != 1 {
+		t.Errorf("expected node 3 to have 1 neighbor after removal; received %v", g.From(3).Len())
+	}
+}
+
+/*
+TestPositionGraph_Edge1
+Description:
+
+	Tests that Edge returns an edge no matter which direction it was
+	added in (the graph is undirected), oriented from the first ID
+	to the second.
+*/
+func TestPositionGraph_Edge1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test
+	forward := g.Edge(0, 1)
+	if forward == nil || forward.From().ID() != 0 || forward.To().ID() != 1 {
+		t.Errorf("expected an edge from 0 to 1; received %v", forward)
+	}
+
+	backward := g.Edge(1, 0)
+	if backward == nil || backward.From().ID() != 1 || backward.To().ID() != 0 {
+		t.Errorf("expected an edge from 1 to 0; received %v", backward)
+	}
+
+	if wght, ok := g.Weight(1, 0); !ok || wght != 1.0 {
+		t.Errorf("expected the weight from 1 to 0 to be 1.0; received %v (%v)", wght, ok)
+	}
+
+	if g.Edge(0, 4) != nil {
+		t.Errorf("expected no edge between 0 and 4")
+	}
+}
+
+/*
+TestPositionGraph_AddNodeAt1
+Description:
+
+	Tests that a node added after another node was removed gets a
+	new ID, instead of overwriting one of the remaining nodes.
+*/
+func TestPositionGraph_AddNodeAt1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+	g.RemoveNode(2)
+
+	// Test
+	x := mat.NewVecDense(2, []float64{5.0, 5.0})
+	n := g.AddNodeAt(x)
+
+	if n.ID() != 6 {
+		t.Errorf("expected the new node to get ID 6; received %v", n.ID())
+	}
+
+	// All of the other nodes should still be in the graph, at their original positions
+	if g.Nodes().Len() != 6 {
+		t.Errorf("expected 6 nodes in the graph; received %v", g.Nodes().Len())
+	}
+
+	lastNode := g.Node(5).(*position_graph.Node)
+	if !mat.Equal(lastNode.Position, mat.NewVecDense(2, []float64{2.0, 1.0})) {
+		t.Errorf("expected node 5 to be untouched; it is now at %v", lastNode.Position)
+	}
+}
+
+/*
+TestPositionGraph_AddNodeWithID1
+Description:
+
+	Tests that AddNodeWithID returns a NodeIDCollisionError for an ID
+	that is already in use, and that AddNodeAt never hands out an ID
+	that was added explicitly.
+*/
+func TestPositionGraph_AddNodeWithID1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+	x := mat.NewVecDense(2, []float64{5.0, 5.0})
+
+	// Test collision
+	_, err := g.AddNodeWithID(3, x)
+	expectedError := position_graph.NodeIDCollisionError{ID: 3}
+	if err != expectedError {
+		t.Errorf("expected error \"%v\"; received \"%v\"", expectedError, err)
+	}
+
+	// Test explicit ID
+	n, err := g.AddNodeWithID(10, x)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n.ID() != 10 {
+		t.Errorf("expected node to have ID 10; received %v", n.ID())
+	}
+
+	// Test allocation after explicit ID
+	if next := g.AddNodeAt(x); next.ID() != 11 {
+		t.Errorf("expected the next allocated ID to be 11; received %v", next.ID())
+	}
+}
+
+/*
+TestPositionGraph_AddEdgeBetween1
+Description:
+
+	Tests that an edge added after another edge was removed does not
+	overwrite one of the remaining edges.
+*/
+func TestPositionGraph_AddEdgeBetween1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+	g.RemoveEdge(0, 2)
+
+	// Test
+	g.AddEdgeBetween(*g.Node(4).(*position_graph.Node), *g.Node(5).(*position_graph.Node))
+
+	expected := [][2]int64{{0, 1}, {1, 3}, {3, 4}, {4, 5}}
+	for _, pair := range expected {
+		if !g.HasEdgeBetween(pair[0], pair[1]) {
+			t.Errorf("expected an edge between %v and %v", pair[0], pair[1])
+		}
+	}
+
+	if g.HasEdgeBetween(0, 2) {
+		t.Errorf("expected the edge between 0 and 2 to stay removed")
+	}
+
+	// Removing the new edge should leave the others in place
+	g.RemoveEdge(4, 5)
+	for _, pair := range expected[:3] {
+		if !g.HasEdgeBetween(pair[0], pair[1]) {
+			t.Errorf("expected an edge between %v and %v after removing (4, 5)", pair[0], pair[1])
+		}
+	}
+}
+
+/*
+TestPositionGraph_AddEdge1
+Description:
+
+	Tests that adding an edge between two nodes that are already
+	connected (in the other direction) replaces the old edge.
+*/
+func TestPositionGraph_AddEdge1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+	n0, n1 := *g.Node(0).(*position_graph.Node), *g.Node(1).(*position_graph.Node)
+
+	// Test
+	g.AddEdgeWithCosts(n1, n0, map[string]float64{"time": 5.0})
+
+	count := 0
+	neighbors := g.From(0)
+	for neighbors.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("expected node 0 to keep 2 neighbors; received %v", count)
+	}
+
+	e, err := g.LookupEdgeBetween(0, 1)
+	if err != nil {
+		t.Fatalf("there was a problem looking up the edge: %v", err)
+	}
+	if time, ok := e.Cost("time"); !ok || time != 5.0 {
+		t.Errorf("expected the new edge's time cost 5; received %v, %v", time, ok)
+	}
+
+	// The old edge's costs are gone
+	g.AddEdgeBetween(n0, n1)
+	e, _ = g.LookupEdgeBetween(0, 1)
+	if _, ok := e.Cost("time"); ok {
+		t.Errorf("expected the replaced edge to have no time cost")
+	}
+}
+
+/*
+TestPositionGraph_LookupNode1
+Description:
+
+	Tests that LookupNode returns existing nodes and a NodeNotFoundError
+	(instead of panicking) for nodes that do not exist.
+*/
+func TestPositionGraph_LookupNode1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test
+	n, err := g.LookupNode(1)
+	if err != nil || n.ID() != 1 {
+		t.Errorf("expected to find node 1; received %v (%v)", n, err)
+	}
+
+	_, err = g.LookupNode(100)
+	expectedError := position_graph.NodeNotFoundError{ID: 100}
+	if err != expectedError {
+		t.Errorf("expected error \"%v\"; received \"%v\"", expectedError, err)
+	}
+}
+
+/*
+TestPositionGraph_Neighbors1
+Description:
+
+	Tests that Neighbors matches From for existing nodes and returns a
+	NodeNotFoundError (instead of panicking) for nodes that do not exist.
+*/
+func TestPositionGraph_Neighbors1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test
+	neighbors, err := g.Neighbors(0)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if neighbors.Len() != 2 {
+		t.Errorf("Expected 2, got %v", neighbors.Len())
+	}
+
+	_, err = g.Neighbors(101)
+	expectedError := position_graph.NodeNotFoundError{ID: 101}
+	if err != expectedError {
+		t.Errorf("expected error \"%v\"; received \"%v\"", expectedError, err)
+	}
+}
+
+/*
+TestPositionGraph_WeightedEdgeBetween1
+Description:
+
+	Tests that WeightedEdgeBetween returns nil (instead of panicking)
+	when there is no edge between the two nodes, and that
+	LookupEdgeBetween returns an EdgeNotFoundError.
+*/
+func TestPositionGraph_WeightedEdgeBetween1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPositionGraph1()
+
+	// Test
+	if e := g.WeightedEdgeBetween(0, 4); e != nil {
+		t.Errorf("expected no edge between 0 and 4; received %v", e)
+	}
+
+	_, err := g.LookupEdgeBetween(0, 4)
+	expectedError := position_graph.EdgeNotFoundError{From: 0, To: 4}
+	if err != expectedError {
+		t.Errorf("expected error \"%v\"; received \"%v\"", expectedError, err)
+	}
+
+	e, err := g.LookupEdgeBetween(1, 0)
+	if err != nil || e.Weight() != 1.0 {
+		t.Errorf("expected to find the edge between 1 and 0; received %v (%v)", e, err)
+	}
+}
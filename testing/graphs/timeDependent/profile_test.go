@@ -0,0 +1,222 @@
+package time_dependent_graph_test
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	time_dependent_graph "github.com/GraphPathPlanning.go/graphs/timeDependent"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"testing"
+)
+
+/*
+profile_test.go
+Description:
+
+	Tests the travel time profiles and the time-dependent graph.
+*/
+
+/*
+TestProfile_TravelTime1
+Description:
+
+	Tests interpolation between breakpoints and the constant travel
+	times before the first and after the last breakpoint.
+*/
+func TestProfile_TravelTime1(t *testing.T) {
+	// Setup
+	profile, err := time_dependent_graph.NewProfile(
+		time_dependent_graph.Breakpoint{Time: 7.0, TravelTime: 1.0},
+		time_dependent_graph.Breakpoint{Time: 8.0, TravelTime: 3.0},
+		time_dependent_graph.Breakpoint{Time: 10.0, TravelTime: 1.0},
+	)
+	if err != nil {
+		t.Fatalf("there was a problem creating the profile: %v", err)
+	}
+
+	// Test
+	for _, tc := range []struct{ departure, expected float64 }{
+		{0.0, 1.0}, {7.0, 1.0}, {7.5, 2.0}, {8.0, 3.0}, {9.0, 2.0}, {10.0, 1.0}, {30.0, 1.0},
+	} {
+		if travelTime := profile.TravelTime(tc.departure); math.Abs(travelTime-tc.expected) > 1e-12 {
+			t.Errorf("expected a travel time of %v when leaving at %v; received %v", tc.expected, tc.departure, travelTime)
+		}
+	}
+
+	if profile.Arrival(7.5) != 9.5 || profile.MinTravelTime() != 1.0 {
+		t.Errorf("expected to arrive at 9.5 and a smallest travel time of 1; received %v and %v", profile.Arrival(7.5), profile.MinTravelTime())
+	}
+}
+
+/*
+TestProfile_TravelTime2
+Description:
+
+	Tests that periodic profiles repeat, and interpolate between the
+	last breakpoint and the first breakpoint of the next period.
+*/
+func TestProfile_TravelTime2(t *testing.T) {
+	// Setup
+	profile, err := time_dependent_graph.NewPeriodicProfile(
+		24.0,
+		time_dependent_graph.Breakpoint{Time: 6.0, TravelTime: 2.0},
+		time_dependent_graph.Breakpoint{Time: 18.0, TravelTime: 4.0},
+	)
+	if err != nil {
+		t.Fatalf("there was a problem creating the profile: %v", err)
+	}
+
+	// Test
+	for _, tc := range []struct{ departure, expected float64 }{
+		{6.0, 2.0}, {12.0, 3.0}, {18.0, 4.0}, {0.0, 3.0}, {30.0, 2.0}, {-12.0, 3.0}, {48.0 + 21.0, 3.5},
+	} {
+		if travelTime := profile.TravelTime(tc.departure); math.Abs(travelTime-tc.expected) > 1e-12 {
+			t.Errorf("expected a travel time of %v when leaving at %v; received %v", tc.expected, tc.departure, travelTime)
+		}
+	}
+}
+
+/*
+TestProfile_NewProfile1
+Description:
+
+	Tests that profiles with invalid breakpoints or FIFO violations are rejected.
+*/
+func TestProfile_NewProfile1(t *testing.T) {
+	// Test
+	_, err := time_dependent_graph.NewProfile()
+	if _, ok := err.(time_dependent_graph.InvalidBreakpointError); !ok {
+		t.Errorf("expected an InvalidBreakpointError for an empty profile; received %v", err)
+	}
+
+	_, err = time_dependent_graph.NewProfile(
+		time_dependent_graph.Breakpoint{Time: 1.0, TravelTime: 1.0},
+		time_dependent_graph.Breakpoint{Time: 1.0, TravelTime: 1.0},
+	)
+	if err != (time_dependent_graph.InvalidBreakpointError{Index: 1, Reason: "the time is not after the previous breakpoint"}) {
+		t.Errorf("expected an InvalidBreakpointError for unsorted breakpoints; received %v", err)
+	}
+
+	// Leaving at 0 arrives at 10, but leaving at 1 arrives at 3
+	_, err = time_dependent_graph.NewProfile(
+		time_dependent_graph.Breakpoint{Time: 0.0, TravelTime: 10.0},
+		time_dependent_graph.Breakpoint{Time: 1.0, TravelTime: 2.0},
+	)
+	if _, ok := err.(time_dependent_graph.FIFOViolationError); !ok {
+		t.Errorf("expected a FIFOViolationError; received %v", err)
+	}
+
+	_, err = time_dependent_graph.NewPeriodicProfile(
+		24.0,
+		time_dependent_graph.Breakpoint{Time: 0.0, TravelTime: 1.0},
+		time_dependent_graph.Breakpoint{Time: 20.0, TravelTime: 10.0},
+	)
+	if _, ok := err.(time_dependent_graph.FIFOViolationError); !ok {
+		t.Errorf("expected a FIFOViolationError across the end of the period; received %v", err)
+	}
+}
+
+/*
+TestProfile_NewProfile2
+Description:
+
+	Tests that profiles with non-finite times or periods are rejected.
+*/
+func TestProfile_NewProfile2(t *testing.T) {
+	// Test
+	for _, time := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := time_dependent_graph.NewProfile(
+			time_dependent_graph.Breakpoint{Time: 0.0, TravelTime: 1.0},
+			time_dependent_graph.Breakpoint{Time: time, TravelTime: 1.0},
+		)
+		if err != (time_dependent_graph.InvalidBreakpointError{Index: 1, Reason: "the time is not finite"}) {
+			t.Errorf("expected an InvalidBreakpointError for the time %v; received %v", time, err)
+		}
+	}
+
+	_, err := time_dependent_graph.NewProfile(time_dependent_graph.Breakpoint{Time: 0.0, TravelTime: math.NaN()})
+	if _, ok := err.(time_dependent_graph.InvalidBreakpointError); !ok {
+		t.Errorf("expected an InvalidBreakpointError for a NaN travel time; received %v", err)
+	}
+
+	for _, period := range []float64{math.NaN(), math.Inf(1), 0.0, -24.0} {
+		_, err := time_dependent_graph.NewPeriodicProfile(
+			period,
+			time_dependent_graph.Breakpoint{Time: 0.0, TravelTime: 1.0},
+		)
+		if _, ok := err.(time_dependent_graph.InvalidPeriodError); !ok {
+			t.Errorf("expected an InvalidPeriodError for the period %v; received %v", period, err)
+		}
+	}
+}
+
+/*
+TestProfile_ConstantProfile1
+Description:
+
+	Tests that a constant profile keeps its travel time at every
+	departure, and that invalid travel times are rejected.
+*/
+func TestProfile_ConstantProfile1(t *testing.T) {
+	// Test
+	profile, err := time_dependent_graph.ConstantProfile(3.0)
+	if err != nil {
+		t.Fatalf("there was a problem creating the profile: %v", err)
+	}
+	for _, departure := range []float64{-10.0, 0.0, 1e6} {
+		if travelTime := profile.TravelTime(departure); travelTime != 3.0 {
+			t.Errorf("expected a travel time of 3 when leaving at %v; received %v", departure, travelTime)
+		}
+	}
+
+	for _, travelTime := range []float64{-5.0, math.NaN(), math.Inf(-1)} {
+		if _, err := time_dependent_graph.ConstantProfile(travelTime); err == nil {
+			t.Errorf("expected an InvalidBreakpointError for the travel time %v", travelTime)
+		} else if _, ok := err.(time_dependent_graph.InvalidBreakpointError); !ok {
+			t.Errorf("expected an InvalidBreakpointError for the travel time %v; received %v", travelTime, err)
+		}
+	}
+}
+
+/*
+TestGraph_TravelTime1
+Description:
+
+	Tests that edges use their profile in each direction, and the
+	weight of the underlying graph otherwise.
+*/
+func TestGraph_TravelTime1(t *testing.T) {
+	// Setup
+	pg := position_graph.New()
+	n0 := pg.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := pg.AddNodeAt(mat.NewVecDense(2, []float64{3.0, 4.0}))
+	n2 := pg.AddNodeAt(mat.NewVecDense(2, []float64{6.0, 0.0}))
+	pg.AddEdgeBetween(n0, n1)
+
+	g := time_dependent_graph.New(pg)
+	rushHour, _ := time_dependent_graph.NewProfile(
+		time_dependent_graph.Breakpoint{Time: 8.0, TravelTime: 5.0},
+		time_dependent_graph.Breakpoint{Time: 9.0, TravelTime: 15.0},
+	)
+
+	// Test
+	if err := g.SetProfile(n0.ID(), n1.ID(), rushHour); err != nil {
+		t.Fatalf("there was a problem setting the profile: %v", err)
+	}
+
+	if travelTime, ok := g.TravelTime(n0.ID(), n1.ID(), 9.0); !ok || travelTime != 15.0 {
+		t.Errorf("expected the profile's travel time of 15; received %v, %v", travelTime, ok)
+	}
+
+	if travelTime, ok := g.TravelTime(n1.ID(), n0.ID(), 9.0); !ok || travelTime != 5.0 {
+		t.Errorf("expected the edge's weight of 5 in the other direction; received %v, %v", travelTime, ok)
+	}
+
+	if _, ok := g.TravelTime(n0.ID(), n2.ID(), 9.0); ok {
+		t.Errorf("expected no travel time without an edge")
+	}
+
+	if err := g.SetProfile(n0.ID(), n2.ID(), rushHour); err != (gppErrors.EdgeNotFound{From: n0.ID(), To: n2.ID()}) {
+		t.Errorf("expected an EdgeNotFound error; received %v", err)
+	}
+}
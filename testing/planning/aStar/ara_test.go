@@ -0,0 +1,173 @@
+package aStar_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	positionGraph2 "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+ara_test.go
+Description:
+
+	Tests the ARA* (anytime) version of the A* algorithm.
+*/
+
+/*
+createRandomGeometricGraph
+Description:
+
+	Creates a graph with nNodes nodes at random positions in a 10 x 10 square,
+	where each node is connected to the nodes that are closer than radius.
+*/
+func createRandomGeometricGraph(seed int64, nNodes int, radius float64) *positionGraph2.PositionGraph {
+	// Constants
+	rng := rand.New(rand.NewSource(seed))
+	g := positionGraph2.New()
+
+	// Algorithm
+	nodes := make([]positionGraph2.Node, nNodes)
+	for idx := range nodes {
+		nodes[idx] = g.AddNodeAt(
+			mat.NewVecDense(2, []float64{10 * rng.Float64(), 10 * rng.Float64()}),
+		)
+	}
+
+	for i := range nodes {
+		for j := i + 1; j < len(nodes); j++ {
+			var diff mat.VecDense
+			diff.SubVec(nodes[i].Position, nodes[j].Position)
+			if mat.Norm(&diff, 2) < radius {
+				g.AddEdgeBetween(nodes[i], nodes[j])
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+TestARA_FindPlanAnytime1
+Description:
+
+	Tests that ARA* reports plans whose costs respect their suboptimality
+	bounds, with bounds that never increase, and that it ends with the optimal plan.
+*/
+func TestARA_FindPlanAnytime1(t *testing.T) {
+	// Setup
+	g := createRandomGeometricGraph(3, 300, 1.2)
+	start, end := int64(0), int64(1)
+
+	optimal, err := aStar.FindPlan(g, start, end, euclideanHeuristicTo(end))
+	if err != nil {
+		t.Fatalf("there was a problem finding the optimal plan: %v", err)
+	}
+
+	// Test
+	var reported []aStar.AnytimePlan
+	result, err := aStar.FindPlanAnytime(
+		context.Background(), g, start, end, euclideanHeuristicTo(end),
+		aStar.AnytimeOptions{InitialEpsilon: 5.0, EpsilonStep: 1.0},
+		func(ap aStar.AnytimePlan) { reported = append(reported, ap) },
+	)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(reported) == 0 {
+		t.Fatalf("expected at least one plan to be reported")
+	}
+
+	for idx, ap := range reported {
+		if ap.Plan.CostToGo > ap.SuboptimalityBound*optimal.CostToGo+1e-9 {
+			t.Errorf(
+				"plan %v costs %v, which is more than %v times the optimal cost %v",
+				idx, ap.Plan.CostToGo, ap.SuboptimalityBound, optimal.CostToGo,
+			)
+		}
+
+		if idx > 0 && ap.SuboptimalityBound > reported[idx-1].SuboptimalityBound {
+			t.Errorf("expected the suboptimality bounds to never increase; received %v", reported)
+		}
+	}
+
+	if result.SuboptimalityBound != 1.0 || math.Abs(result.Plan.CostToGo-optimal.CostToGo) > 1e-9 {
+		t.Errorf(
+			"expected the final plan to be optimal (cost %v); received cost %v with bound %v",
+			optimal.CostToGo, result.Plan.CostToGo, result.SuboptimalityBound,
+		)
+	}
+
+	ids := result.Plan.NodeIDs()
+	if ids[0] != start || ids[len(ids)-1] != end {
+		t.Errorf("expected plan to go from %v to %v; received %v", start, end, ids)
+	}
+}
+
+/*
+TestARA_FindPlanAnytime2
+Description:
+
+	Tests that ARA* returns the best plan found so far when the context
+	is cancelled after the first plan, and an error when it is cancelled
+	before any plan is found.
+*/
+func TestARA_FindPlanAnytime2(t *testing.T) {
+	// Setup
+	g := createRandomGeometricGraph(3, 300, 1.2)
+	start, end := int64(0), int64(1)
+
+	// Test: cancel after the first plan
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nReported := 0
+	result, err := aStar.FindPlanAnytime(
+		ctx, g, start, end, euclideanHeuristicTo(end),
+		aStar.AnytimeOptions{InitialEpsilon: 5.0},
+		func(aStar.AnytimePlan) {
+			nReported++
+			cancel()
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected the first plan to be returned; received %v", err)
+	}
+
+	if nReported != 1 || result.Epsilon != 5.0 {
+		t.Errorf("expected only the first plan (epsilon 5) to be found; received %v plans, epsilon %v", nReported, result.Epsilon)
+	}
+
+	// Test: cancel before the first plan
+	_, err = aStar.FindPlanAnytime(ctx, g, start, end, euclideanHeuristicTo(end), aStar.AnytimeOptions{}, nil)
+
+	var cancelled gppErrors.SearchCancelled
+	if !errors.As(err, &cancelled) {
+		t.Errorf("expected a SearchCancelled error; received %v", err)
+	}
+}
+
+/*
+TestARA_FindPlanAnytime3
+Description:
+
+	Tests that ARA* reports a NoPathFound error when the end cannot be reached.
+*/
+func TestARA_FindPlanAnytime3(t *testing.T) {
+	// Setup
+	g := positionGraph2.New()
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+
+	// Test
+	_, err := aStar.FindPlanAnytime(context.Background(), g, n1.ID(), n2.ID(), nil, aStar.AnytimeOptions{}, nil)
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+}
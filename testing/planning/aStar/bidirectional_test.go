@@ -0,0 +1,125 @@
+package aStar_test
+
+import (
+	positionGraph2 "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+bidirectional_test.go
+Description:
+
+	Tests the bidirectional version of the A* algorithm.
+*/
+
+/*
+euclideanHeuristicTo
+Description:
+
+	Returns a heuristic that estimates the Euclidean distance
+	from a planning node's graph node to the node with ID target.
+*/
+func euclideanHeuristicTo(target int64) func(*aStar.PlanningNode) float64 {
+	return func(currPN *aStar.PlanningNode) float64 {
+		// Setup
+		wu := currPN.Graph
+
+		targetNode := wu.Node(target).(*positionGraph2.Node)
+		currNode := wu.Node(currPN.CurrentGraphNode.ID()).(*positionGraph2.Node)
+
+		// Algorithm
+		var diff mat.VecDense
+		diff.SubVec(targetNode.Position, currNode.Position)
+		return mat.Norm(&diff, 2)
+	}
+}
+
+/*
+TestBidirectional_FindPlanBidirectional1
+Description:
+
+	Tests that bidirectional A* finds plans with the same cost as A*
+	on a random geometric graph.
+*/
+func TestBidirectional_FindPlanBidirectional1(t *testing.T) {
+	// Setup Graph
+	rng := rand.New(rand.NewSource(7))
+	g := positionGraph2.New()
+
+	nNodes := 60
+	nodes := make([]positionGraph2.Node, nNodes)
+	for idx := range nodes {
+		nodes[idx] = g.AddNodeAt(
+			mat.NewVecDense(2, []float64{10 * rng.Float64(), 10 * rng.Float64()}),
+		)
+	}
+	for idx := 0; idx < 3*nNodes; idx++ {
+		a, b := rng.Intn(nNodes), rng.Intn(nNodes)
+		if a != b {
+			g.AddEdgeBetween(nodes[a], nodes[b])
+		}
+	}
+
+	// Test
+	for trial := 0; trial < 20; trial++ {
+		start, end := int64(rng.Intn(nNodes)), int64(rng.Intn(nNodes))
+
+		expected, err := aStar.FindPlan(g, start, end, euclideanHeuristicTo(end))
+		p, err2 := aStar.FindPlanBidirectional(
+			g, start, end,
+			euclideanHeuristicTo(end), euclideanHeuristicTo(start),
+		)
+
+		if (err == nil) != (err2 == nil) {
+			t.Errorf("expected the same error from both planners; received %v and %v", err, err2)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+			t.Errorf(
+				"expected plan from %v to %v to cost %v; received %v",
+				start, end, expected.CostToGo, p.CostToGo,
+			)
+		}
+
+		ids := p.NodeIDs()
+		if ids[0] != start || ids[len(ids)-1] != end {
+			t.Errorf("expected plan to go from %v to %v; received %v", start, end, ids)
+		}
+	}
+}
+
+/*
+TestBidirectional_FindPlanBidirectional2
+Description:
+
+	Tests that bidirectional A* works without heuristics.
+*/
+func TestBidirectional_FindPlanBidirectional2(t *testing.T) {
+	// Setup Graph
+	g := positionGraph2.New()
+
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 0.0}))
+	g.AddEdgeBetween(n1, n2)
+	g.AddEdgeBetween(n2, n3)
+
+	// Test
+	p, err := aStar.FindPlanBidirectional(g, n1.ID(), n3.ID(), nil, nil)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p.Sequence) != 3 || p.CostToGo != 2.0 {
+		t.Errorf("expected plan [0 1 2] with cost 2; received %v (cost %v)", p.NodeIDs(), p.CostToGo)
+	}
+}
@@ -0,0 +1,211 @@
+package aStar_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"slices"
+	"testing"
+)
+
+/*
+heuristic_test.go
+Description:
+
+	Tests the goal-aware heuristics of A* and the search for the nearest of several goals.
+*/
+
+/*
+createLine
+Description:
+
+	Creates a PositionGraph of n nodes on the x axis, where node i is at
+	(i, 0) and is connected to node i + 1.
+*/
+func createLine(n int) *position_graph.PositionGraph {
+	g := position_graph.New()
+	var previous position_graph.Node
+	for idx := 0; idx < n; idx++ {
+		node := g.AddNodeAt(mat.NewVecDense(2, []float64{float64(idx), 0.0}))
+		if idx > 0 {
+			g.AddEdgeBetween(previous, node)
+		}
+		previous = node
+	}
+
+	return g
+}
+
+/*
+nearestX
+Description:
+
+	A goal-aware heuristic for createLine: the distance along
+	the x axis to the nearest goal.
+*/
+func nearestX(g graph.Weighted, current graph.Node, goals []int64) float64 {
+	x := current.(*position_graph.Node).Position.AtVec(0)
+
+	nearest := math.Inf(1)
+	for _, goal := range goals {
+		nearest = min(nearest, math.Abs(g.Node(goal).(*position_graph.Node).Position.AtVec(0)-x))
+	}
+	return nearest
+}
+
+/*
+TestFindPlanToGoals1
+Description:
+
+	Tests that the search stops at the nearest goal, and that
+	the heuristic is told every goal.
+*/
+func TestFindPlanToGoals1(t *testing.T) {
+	// Setup
+	g := createLine(10)
+	var received []int64
+	heuristic := aStar.HeuristicFunc(func(g graph.Weighted, current graph.Node, goals []int64) float64 {
+		received = goals
+		return nearestX(g, current, goals)
+	})
+
+	// Test
+	p, err := aStar.FindPlanToGoals(g, 4, []int64{0, 9, 6}, heuristic)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if !slices.Equal(p.NodeIDs(), []int64{4, 5, 6}) || p.CostToGo != 2.0 {
+		t.Errorf("expected the plan 4-5-6 that costs 2; received %v, %v", p.NodeIDs(), p.CostToGo)
+	}
+
+	if !slices.Equal(received, []int64{0, 9, 6}) {
+		t.Errorf("expected the heuristic to receive the goals [0 9 6]; received %v", received)
+	}
+
+	// Starting at a goal
+	p, err = aStar.FindPlanToGoals(g, 9, []int64{0, 9}, nil)
+	if err != nil || len(p.Sequence) != 1 {
+		t.Errorf("expected a plan with only the start; received %v, %v", p, err)
+	}
+}
+
+/*
+TestFindPlanToGoals2
+Description:
+
+	Tests the errors of FindPlanToGoals.
+*/
+func TestFindPlanToGoals2(t *testing.T) {
+	// Setup
+	g := createLine(3)
+
+	// Test
+	if _, err := aStar.FindPlanToGoals(g, 0, nil, nil); !errors.As(err, &gppErrors.NoPathFound{}) {
+		t.Errorf("expected a NoPathFound error without goals; received %v", err)
+	}
+
+	if _, err := aStar.FindPlanToGoals(g, 0, []int64{2, 42}, nil); !errors.As(err, &gppErrors.GoalNodeNotFound{}) {
+		t.Errorf("expected a GoalNodeNotFound error; received %v", err)
+	}
+
+	if _, err := aStar.FindPlanToGoals(g, 42, []int64{2}, nil); !errors.As(err, &gppErrors.StartNodeNotFound{}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestFromFunc1
+Description:
+
+	Tests that heuristics with the old signature still receive
+	the planning nodes of the search through FromFunc and Bind.
+*/
+func TestFromFunc1(t *testing.T) {
+	// Setup
+	g := createLine(5)
+	sawPredecessor := false
+	old := func(pn *aStar.PlanningNode) float64 {
+		if pn.PreviousInPlan != nil {
+			sawPredecessor = true
+		}
+		return 4.0 - pn.CurrentGraphNode.(*position_graph.Node).Position.AtVec(0)
+	}
+
+	// Test
+	p, err := aStar.FindPlanToGoals(g, 0, []int64{4}, aStar.FromFunc(old))
+	if err != nil || p.CostToGo != 4.0 {
+		t.Fatalf("expected a plan that costs 4; received %v, %v", p, err)
+	}
+
+	if !sawPredecessor {
+		t.Errorf("expected the heuristic to receive the planning nodes of the search")
+	}
+
+	if estimate := aStar.FromFunc(old).Estimate(g, g.Node(1), []int64{4}); estimate != 3.0 {
+		t.Errorf("expected the adapted heuristic to estimate 3; received %v", estimate)
+	}
+
+	if estimate := aStar.Bind(nil, 4)(&aStar.PlanningNode{Graph: g, CurrentGraphNode: g.Node(1)}); estimate != 0.0 {
+		t.Errorf("expected a nil heuristic to estimate 0; received %v", estimate)
+	}
+}
+
+/*
+TestHeuristicFunc1
+Description:
+
+	Tests that nil heuristics of every kind estimate 0 and
+	let the search find the optimal plan.
+*/
+func TestHeuristicFunc1(t *testing.T) {
+	// Setup
+	g := createLine(5)
+
+	// Test
+	for name, h := range map[string]aStar.Heuristic{
+		"FromFunc":      aStar.FromFunc(nil),
+		"HeuristicFunc": aStar.HeuristicFunc(nil),
+	} {
+		if estimate := h.Estimate(g, g.Node(1), []int64{4}); estimate != 0.0 {
+			t.Errorf("expected a nil %v to estimate 0; received %v", name, estimate)
+		}
+
+		p, err := aStar.FindPlanToGoals(g, 0, []int64{4}, h)
+		if err != nil || p.CostToGo != 4.0 {
+			t.Errorf("expected a nil %v to give a plan that costs 4; received %v, %v", name, p, err)
+		}
+	}
+}
+
+/*
+TestPlanner_Plan1
+Description:
+
+	Tests that a Planner with a goal-aware heuristic can be reused
+	for queries with different goals.
+*/
+func TestPlanner_Plan1(t *testing.T) {
+	// Setup
+	g := createLine(8)
+	planner := aStar.Planner{GoalHeuristic: aStar.HeuristicFunc(nearestX)}
+
+	// Test
+	for _, query := range [][2]int64{{0, 7}, {7, 2}, {3, 3}} {
+		p, err := planner.Plan(context.Background(), g, query[0], query[1])
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+
+		expected, _ := djikstra.FindPlan(g, query[0], query[1])
+		if !slices.Equal(p.NodeIDs(), expected.NodeIDs()) {
+			t.Errorf("expected the plan %v; received %v", expected.NodeIDs(), p.NodeIDs())
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package aStar_test
 
 import (
+	"context"
+	"errors"
 	"github.com/GraphPathPlanning.go/gppErrors"
 	positionGraph2 "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
 	"github.com/GraphPathPlanning.go/planning/aStar"
 	"gonum.org/v1/gonum/mat"
+	"math"
 	"testing"
+	"time"
 )
 
 /*
@@ -53,7 +58,8 @@ func TestPlan_FindPlan1(t *testing.T) {
 		return mat.Norm(&diff, 2)
 	}
 
-	// Apply Plan method
+	// Apply Plan method (the aStar.Plan alias still names its plans)
+	var p1 *aStar.Plan
 	p1, err := aStar.FindPlan(g, n1.ID(), n2.ID(), simpleHeuristic)
 	if err != nil {
 		t.Errorf("there was a problem finding the plan: %v", err)
@@ -126,3 +132,171 @@ func TestPlan_FindPlan2(t *testing.T) {
 	}
 
 }
+
+/*
+TestPlan_FindPlan3
+Description:
+
+	Tests that FindPlan terminates quickly on a graph with many cycles
+	(a 30x30 lattice) and that the plan it returns is optimal.
+*/
+func TestPlan_FindPlan3(t *testing.T) {
+	// Setup Graph
+	n := 30
+	g := positionGraph2.New()
+
+	nodes := make([]positionGraph2.Node, n*n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			nodes[row*n+col] = g.AddNodeAt(
+				mat.NewVecDense(2, []float64{float64(col), float64(row)}),
+			)
+		}
+	}
+
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			if col+1 < n {
+				g.AddEdgeBetween(nodes[row*n+col], nodes[row*n+col+1])
+			}
+			if row+1 < n {
+				g.AddEdgeBetween(nodes[row*n+col], nodes[(row+1)*n+col])
+			}
+		}
+	}
+
+	// Setup Path Planning Heuristic
+	goalIdx := nodes[n*n-1].ID()
+	simpleHeuristic := func(currPN *aStar.PlanningNode) float64 {
+		// Setup
+		currentIdx := currPN.CurrentGraphNode.ID()
+		wu := currPN.Graph
+
+		goalNode := wu.Node(goalIdx).(*positionGraph2.Node)
+		currNode := wu.Node(currentIdx).(*positionGraph2.Node)
+
+		// Algorithm
+		var diff mat.VecDense
+		diff.SubVec(goalNode.Position, currNode.Position)
+		return mat.Norm(&diff, 2)
+	}
+
+	// Apply Plan method
+	p1, err := aStar.FindPlan(g, nodes[0].ID(), goalIdx, simpleHeuristic)
+	if err != nil {
+		t.Errorf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p1.Sequence) != 2*n-1 {
+		t.Errorf(
+			"expected %v nodes in the plan; found %v",
+			2*n-1,
+			len(p1.Sequence),
+		)
+	}
+
+	if math.Abs(p1.CostToGo-float64(2*(n-1))) > 1e-6 {
+		t.Errorf(
+			"expected plan cost to be %v; received %v",
+			2*(n-1),
+			p1.CostToGo,
+		)
+	}
+}
+
+/*
+TestPlan_FindPlanWithContext1
+Description:
+
+	Tests that FindPlanWithContext stops with a SearchCancelled error
+	(that wraps context.DeadlineExceeded) when the deadline has passed.
+*/
+func TestPlan_FindPlanWithContext1(t *testing.T) {
+	// Setup Graph
+	g := positionGraph2.New()
+
+	n1 := g.AddNodeAt(
+		mat.NewVecDense(2, []float64{1.0, 2.0}),
+	)
+	n2 := g.AddNodeAt(
+		mat.NewVecDense(2, []float64{2.0, 2.0}),
+	)
+
+	g.AddEdgeBetween(n1, n2)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	// Apply Plan method
+	_, err := aStar.FindPlanWithContext(
+		ctx, g, n1.ID(), n2.ID(),
+		func(*aStar.PlanningNode) float64 { return 0.0 },
+		planning.Budget{},
+	)
+
+	var cancelled gppErrors.SearchCancelled
+	if !errors.As(err, &cancelled) {
+		t.Errorf("expected a SearchCancelled error; received %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to wrap context.DeadlineExceeded; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan4
+Description:
+
+	Tests that FindPlan returns a StartNodeNotFound error (instead of
+	panicking) when the start node is not in the graph.
+*/
+func TestPlan_FindPlan4(t *testing.T) {
+	// Setup Graph
+	g := positionGraph2.New()
+
+	n1 := g.AddNodeAt(
+		mat.NewVecDense(2, []float64{1.0, 2.0}),
+	)
+
+	// Apply Plan method
+	_, err := aStar.FindPlan(
+		g, 42, n1.ID(),
+		func(*aStar.PlanningNode) float64 { return 0.0 },
+	)
+	if err != (gppErrors.StartNodeNotFound{ID: 42}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan5
+Description:
+
+	Tests that FindPlan returns a NoPathFound error when the goal
+	can only be reached by driving the wrong way down a one-way edge.
+*/
+func TestPlan_FindPlan5(t *testing.T) {
+	// Setup Graph
+	g := positionGraph2.NewDirected()
+
+	n1 := g.AddNodeAt(
+		mat.NewVecDense(2, []float64{1.0, 2.0}),
+	)
+	n2 := g.AddNodeAt(
+		mat.NewVecDense(2, []float64{2.0, 2.0}),
+	)
+
+	g.AddEdgeBetween(n2, n1)
+
+	// Apply Plan method
+	zeroHeuristic := func(*aStar.PlanningNode) float64 { return 0.0 }
+	if _, err := aStar.FindPlan(g, n2.ID(), n1.ID(), zeroHeuristic); err != nil {
+		t.Errorf("there was a problem finding the plan: %v", err)
+	}
+
+	_, err := aStar.FindPlan(g, n1.ID(), n2.ID(), zeroHeuristic)
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+}
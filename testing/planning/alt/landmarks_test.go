@@ -0,0 +1,287 @@
+package alt_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"github.com/GraphPathPlanning.go/planning/alt"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+/*
+landmarks_test.go
+Description:
+
+	Tests the selection of landmarks and the ALT heuristic.
+*/
+
+/*
+createRandomGraph
+Description:
+
+	Creates a random (directed or undirected) graph with n nodes whose
+	edges have random weights in [1, 10). The graph is sparse, so it
+	usually has nodes that cannot reach each other.
+*/
+func createRandomGraph(rng *rand.Rand, n int64, directed bool) graph.Weighted {
+	var g interface {
+		graph.Weighted
+		SetWeightedEdge(graph.WeightedEdge)
+		NewWeightedEdge(from, to graph.Node, weight float64) graph.WeightedEdge
+		AddNode(graph.Node)
+	}
+	if directed {
+		g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	} else {
+		g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	}
+
+	for id := int64(0); id < n; id++ {
+		g.AddNode(simple.Node(id))
+	}
+	for from := int64(0); from < n; from++ {
+		for to := int64(0); to < n; to++ {
+			if from != to && rng.Float64() < 2.0/float64(n) {
+				g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(from), simple.Node(to), 1.0+9.0*rng.Float64()))
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+createRoadGrid
+Description:
+
+	Creates an undirected n x n grid whose edges have weights in [1, 3),
+	where node x*n + y is at column x and row y.
+*/
+func createRoadGrid(rng *rand.Rand, n int64) *simple.WeightedUndirectedGraph {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for x := int64(0); x < n; x++ {
+		for y := int64(0); y < n; y++ {
+			if x+1 < n {
+				g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(n*x+y), simple.Node(n*(x+1)+y), 1.0+2.0*rng.Float64()))
+			}
+			if y+1 < n {
+				g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(n*x+y), simple.Node(n*x+y+1), 1.0+2.0*rng.Float64()))
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+TestLandmarks_Estimate1
+Description:
+
+	Tests that the estimates of both strategies never overestimate the
+	cost found by Djikstra's algorithm, are only infinite when there is
+	no path, and are consistent along every edge.
+*/
+func TestLandmarks_Estimate1(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(23))
+	const n = 20
+
+	// Test
+	for trial := 0; trial < 8; trial++ {
+		g := createRandomGraph(rng, n, trial%2 == 0)
+		options := alt.Options{Count: 4, Strategy: alt.Farthest}
+		if trial%4 >= 2 {
+			options.Strategy = alt.Avoid
+			options.Seed = int64(trial)
+		}
+
+		l, err := alt.Build(context.Background(), g, options)
+		if err != nil {
+			t.Fatalf("there was a problem building the landmarks: %v", err)
+		}
+
+		for from := int64(0); from < n; from++ {
+			for to := int64(0); to < n; to++ {
+				estimate := l.Estimate(from, to)
+
+				p, err := djikstra.FindPlan(g, from, to)
+				if err != nil {
+					continue
+				}
+				if estimate > p.CostToGo+1e-9 {
+					t.Errorf("expected estimate from %v to %v to be at most %v; received %v", from, to, p.CostToGo, estimate)
+				}
+
+				// Consistency: h(from) <= w(from, next) + h(next)
+				neighbors := g.From(from)
+				for neighbors.Next() {
+					next := neighbors.Node().ID()
+					w, _ := g.Weight(from, next)
+					if estimate > w+l.Estimate(next, to)+1e-9 {
+						t.Errorf("expected estimates to %v to be consistent along the edge %v -> %v", to, from, next)
+					}
+				}
+			}
+		}
+	}
+}
+
+/*
+TestLandmarks_Heuristic1
+Description:
+
+	Tests that A* with the ALT heuristic finds plans as short as
+	Djikstra's algorithm on a road grid, while evaluating far
+	fewer nodes than A* without a heuristic.
+*/
+func TestLandmarks_Heuristic1(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(4))
+	const n = 30
+	g := createRoadGrid(rng, n)
+
+	for _, strategy := range []alt.Strategy{alt.Farthest, alt.Avoid} {
+		l, err := alt.Build(context.Background(), g, alt.Options{Strategy: strategy})
+		if err != nil {
+			t.Fatalf("there was a problem building the landmarks: %v", err)
+		}
+
+		// Test
+		altEvaluations, blindEvaluations := 0, 0
+		for trial := 0; trial < 20; trial++ {
+			start, end := rng.Int63n(n*n), rng.Int63n(n*n)
+
+			expected, err := djikstra.FindPlan(g, start, end)
+			if err != nil {
+				t.Fatalf("there was a problem finding the plan: %v", err)
+			}
+
+			heuristic := l.Heuristic(end)
+			p, err := aStar.FindPlan(g, start, end, func(pn *aStar.PlanningNode) float64 {
+				altEvaluations++
+				return heuristic(pn)
+			})
+			if err != nil {
+				t.Fatalf("there was a problem finding the plan: %v", err)
+			}
+
+			if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+				t.Errorf("expected plan from %v to %v to cost %v; received %v", start, end, expected.CostToGo, p.CostToGo)
+			}
+
+			_, _ = aStar.FindPlan(g, start, end, func(pn *aStar.PlanningNode) float64 {
+				blindEvaluations++
+				return 0.0
+			})
+		}
+
+		if 2*altEvaluations > blindEvaluations {
+			t.Errorf("expected strategy %v to evaluate less than half as many nodes as A* without a heuristic; received %v and %v", strategy, altEvaluations, blindEvaluations)
+		}
+	}
+}
+
+/*
+TestBuild1
+Description:
+
+	Tests that Build selects distinct landmarks (at most one per node) in
+	the same way for the same seed, and the errors of Build and New.
+*/
+func TestBuild1(t *testing.T) {
+	// Setup
+	g := createRoadGrid(rand.New(rand.NewSource(1)), 5)
+
+	// Test
+	l1, err := alt.Build(context.Background(), g, alt.Options{Count: 6, Strategy: alt.Avoid, Seed: 3})
+	if err != nil {
+		t.Fatalf("there was a problem building the landmarks: %v", err)
+	}
+	l2, _ := alt.Build(context.Background(), g, alt.Options{Count: 6, Strategy: alt.Avoid, Seed: 3})
+
+	ids := l1.IDs()
+	if len(ids) != 6 || !slices.Equal(ids, l2.IDs()) {
+		t.Errorf("expected the same 6 landmarks twice; received %v and %v", ids, l2.IDs())
+	}
+	slices.Sort(ids)
+	if len(slices.Compact(ids)) != 6 {
+		t.Errorf("expected distinct landmarks; received %v", l1.IDs())
+	}
+
+	all, _ := alt.Build(context.Background(), g, alt.Options{Count: 100})
+	if len(all.IDs()) != 25 {
+		t.Errorf("expected one landmark per node; received %v", len(all.IDs()))
+	}
+
+	// The landmarks of Farthest are at the corners of the grid
+	corners, _ := alt.Build(context.Background(), g, alt.Options{Count: 2})
+	for _, id := range corners.IDs() {
+		if !slices.Contains([]int64{0, 4, 20, 24}, id) {
+			t.Errorf("expected landmark %v to be a corner of the grid", id)
+		}
+	}
+
+	// Errors
+	if _, err := alt.New(context.Background(), g, 0, 42); err != (gppErrors.NodeNotFound{ID: 42, Role: "landmark"}) {
+		t.Errorf("expected a NodeNotFound error for landmark 42; received %v", err)
+	}
+
+	negative := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	negative.SetWeightedEdge(negative.NewWeightedEdge(simple.Node(0), simple.Node(1), -1.0))
+	if _, err := alt.Build(context.Background(), negative, alt.Options{}); !errors.As(err, &gppErrors.UnsupportedGraph{}) {
+		t.Errorf("expected an UnsupportedGraph error; received %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := alt.Build(ctx, g, alt.Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled build; received %v", err)
+	}
+}
+
+/*
+TestLandmarks_ToNearestGoal1
+Description:
+
+	Tests that the goal-aware ALT heuristic finds the
+	nearest of several goals.
+*/
+func TestLandmarks_ToNearestGoal1(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(25))
+	const n = 15
+	g := createRoadGrid(rng, n)
+
+	l, err := alt.Build(context.Background(), g, alt.Options{})
+	if err != nil {
+		t.Fatalf("there was a problem building the landmarks: %v", err)
+	}
+
+	// Test
+	for trial := 0; trial < 10; trial++ {
+		start := rng.Int63n(n * n)
+		goals := []int64{rng.Int63n(n * n), rng.Int63n(n * n), rng.Int63n(n * n)}
+
+		p, err := aStar.FindPlanToGoals(g, start, goals, l.ToNearestGoal())
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+
+		nearest := math.Inf(1)
+		for _, goal := range goals {
+			expected, _ := djikstra.FindPlan(g, start, goal)
+			nearest = min(nearest, expected.CostToGo)
+		}
+
+		if math.Abs(p.CostToGo-nearest) > 1e-9 {
+			t.Errorf("expected the plan to the nearest goal to cost %v; received %v", nearest, p.CostToGo)
+		}
+	}
+}
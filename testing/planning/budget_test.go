@@ -0,0 +1,64 @@
+package planning_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"testing"
+)
+
+/*
+budget_test.go
+Description:
+
+	Tests the Budget object.
+*/
+
+/*
+TestBudget_Check1
+Description:
+
+	Tests that the zero Budget never stops a search.
+*/
+func TestBudget_Check1(t *testing.T) {
+	// Setup
+	stats := gppErrors.SearchStatistics{
+		Expansions:   1000000,
+		HeapSize:     1000000,
+		FrontierCost: 1e12,
+	}
+
+	// Test
+	if err := (planning.Budget{}).Check(context.Background(), stats); err != nil {
+		t.Errorf("expected the zero budget to allow the search; received %v", err)
+	}
+}
+
+/*
+TestBudget_Check2
+Description:
+
+	Tests that Check reports the heap size budget when the heap grows too large.
+*/
+func TestBudget_Check2(t *testing.T) {
+	// Setup
+	budget := planning.Budget{MaxHeapSize: 10}
+	stats := gppErrors.SearchStatistics{HeapSize: 11}
+
+	// Test
+	err := budget.Check(context.Background(), stats)
+
+	var exceeded gppErrors.SearchBudgetExceeded
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected a SearchBudgetExceeded error; received %v", err)
+	}
+
+	if exceeded.Budget != planning.MaxHeapSizeBudget {
+		t.Errorf("expected the %v budget to run out; received %v", planning.MaxHeapSizeBudget, exceeded.Budget)
+	}
+
+	if exceeded.Statistics.HeapSize != stats.HeapSize {
+		t.Errorf("expected the error to carry the statistics; received %v", exceeded.Statistics)
+	}
+}
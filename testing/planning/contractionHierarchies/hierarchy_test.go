@@ -0,0 +1,355 @@
+package contractionHierarchies_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/contractionHierarchies"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+hierarchy_test.go
+Description:
+
+	Tests the preprocessing, queries and serialization of contraction hierarchies.
+*/
+
+/*
+createRandomGraph
+Description:
+
+	Creates a random (directed or undirected) graph with n nodes
+	whose edges have random weights in [1, 10).
+*/
+func createRandomGraph(rng *rand.Rand, n int64, directed bool) graph.Weighted {
+	var g interface {
+		graph.Weighted
+		SetWeightedEdge(graph.WeightedEdge)
+		NewWeightedEdge(from, to graph.Node, weight float64) graph.WeightedEdge
+		AddNode(graph.Node)
+	}
+	if directed {
+		g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	} else {
+		g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	}
+
+	for id := int64(0); id < n; id++ {
+		g.AddNode(simple.Node(id))
+	}
+	for from := int64(0); from < n; from++ {
+		for to := int64(0); to < n; to++ {
+			if from != to && rng.Float64() < 3.0/float64(n) {
+				g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(from), simple.Node(to), 1.0+9.0*rng.Float64()))
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+checkAgainstDjikstra
+Description:
+
+	Fails the test if the hierarchy h does not find a valid plan with the
+	same cost as Djikstra's algorithm between every pair of nodes of g
+	(or does not report a missing path when Djikstra's algorithm does).
+*/
+func checkAgainstDjikstra(t *testing.T, g graph.Weighted, h *contractionHierarchies.Hierarchy, n int64) {
+	t.Helper()
+
+	for start := int64(0); start < n; start++ {
+		for end := int64(0); end < n; end++ {
+			expected, expectedErr := djikstra.FindPlan(g, start, end)
+			p, err := h.FindPlan(start, end)
+
+			if expectedErr != nil {
+				if !errors.As(err, &gppErrors.NoPathFound{}) {
+					t.Errorf("expected no path from %v to %v; received %v, %v", start, end, p, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("there was a problem finding the plan from %v to %v: %v", start, end, err)
+			}
+
+			checkPlan(t, g, p, start, end)
+			if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+				t.Errorf("expected plan from %v to %v to cost %v; received %v", start, end, expected.CostToGo, p.CostToGo)
+			}
+		}
+	}
+}
+
+/*
+checkPlan
+Description:
+
+	Fails the test if p is not a path from start to end through g
+	whose edge costs are the weights of g and add up to its cost.
+*/
+func checkPlan(t *testing.T, g graph.Weighted, p *planning.Plan, start, end int64) {
+	t.Helper()
+
+	ids := p.NodeIDs()
+	if ids[0] != start || ids[len(ids)-1] != end || len(p.EdgeCosts) != len(ids)-1 {
+		t.Fatalf("expected plan from %v to %v with one cost per edge; received %v, %v", start, end, ids, p.EdgeCosts)
+	}
+
+	total := 0.0
+	for step := 0; step+1 < len(ids); step++ {
+		w, ok := g.Weight(ids[step], ids[step+1])
+		if !ok || math.Abs(w-p.EdgeCosts[step]) > 1e-9 {
+			t.Errorf("plan %v uses an edge that does not exist or has the wrong cost", ids)
+		}
+		total += p.EdgeCosts[step]
+	}
+
+	if math.Abs(total-p.CostToGo) > 1e-9 {
+		t.Errorf("expected edge costs of %v to add up to %v; received %v", ids, p.CostToGo, total)
+	}
+}
+
+/*
+TestHierarchy_FindPlan1
+Description:
+
+	Tests that queries find plans as short as Djikstra's algorithm
+	on random directed and undirected graphs.
+*/
+func TestHierarchy_FindPlan1(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(22))
+
+	// Test
+	for trial := 0; trial < 10; trial++ {
+		g := createRandomGraph(rng, 25, trial%2 == 0)
+
+		h, err := contractionHierarchies.Build(g)
+		if err != nil {
+			t.Fatalf("there was a problem building the hierarchy: %v", err)
+		}
+
+		checkAgainstDjikstra(t, g, h, 25)
+	}
+}
+
+/*
+TestHierarchy_FindPlan2
+Description:
+
+	Tests that queries still find shortest plans when the witness searches
+	are cut short (which only adds shortcuts), and on a grid-like graph
+	with many shortest paths of the same cost.
+*/
+func TestHierarchy_FindPlan2(t *testing.T) {
+	// Setup
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for x := int64(0); x < 6; x++ {
+		for y := int64(0); y < 6; y++ {
+			if x+1 < 6 {
+				g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(6*x+y), simple.Node(6*(x+1)+y), 1.0))
+			}
+			if y+1 < 6 {
+				g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(6*x+y), simple.Node(6*x+y+1), 1.0))
+			}
+		}
+	}
+
+	// Test
+	for _, limit := range []int{1, 5, 0} {
+		h, err := contractionHierarchies.BuildWithContext(
+			context.Background(), g,
+			contractionHierarchies.BuildOptions{WitnessSettleLimit: limit},
+		)
+		if err != nil {
+			t.Fatalf("there was a problem building the hierarchy: %v", err)
+		}
+
+		checkAgainstDjikstra(t, g, h, 36)
+	}
+}
+
+/*
+TestHierarchy_FindPlan3
+Description:
+
+	Tests queries between a node and itself and with nodes
+	that are not in the hierarchy.
+*/
+func TestHierarchy_FindPlan3(t *testing.T) {
+	// Setup
+	g := createRandomGraph(rand.New(rand.NewSource(3)), 10, true)
+	h, err := contractionHierarchies.Build(g)
+	if err != nil {
+		t.Fatalf("there was a problem building the hierarchy: %v", err)
+	}
+
+	// Test
+	p, err := h.FindPlan(4, 4)
+	if err != nil || len(p.Sequence) != 1 || p.CostToGo != 0.0 {
+		t.Errorf("expected a plan with only node 4; received %v, %v", p, err)
+	}
+
+	if _, err := h.FindPlan(42, 4); !errors.As(err, &gppErrors.StartNodeNotFound{}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+
+	if _, err := h.FindPlan(4, 42); !errors.As(err, &gppErrors.GoalNodeNotFound{}) {
+		t.Errorf("expected a GoalNodeNotFound error; received %v", err)
+	}
+
+	if _, ok := h.Rank(42); ok {
+		t.Errorf("expected node 42 to have no rank")
+	}
+}
+
+/*
+TestBuild1
+Description:
+
+	Tests that graphs with negative weights are rejected
+	and that building stops when the context is cancelled.
+*/
+func TestBuild1(t *testing.T) {
+	// Setup
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(0), simple.Node(1), -1.0))
+
+	// Test
+	if _, err := contractionHierarchies.Build(g); !errors.As(err, &gppErrors.UnsupportedGraph{}) {
+		t.Errorf("expected an UnsupportedGraph error; received %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g2 := createRandomGraph(rand.New(rand.NewSource(1)), 10, false)
+	_, err := contractionHierarchies.BuildWithContext(ctx, g2, contractionHierarchies.BuildOptions{})
+	if !errors.As(err, &gppErrors.SearchCancelled{}) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a SearchCancelled error; received %v", err)
+	}
+}
+
+/*
+TestHierarchy_WriteTo1
+Description:
+
+	Tests that a hierarchy that is written and read back
+	answers queries like the original.
+*/
+func TestHierarchy_WriteTo1(t *testing.T) {
+	// Setup
+	g := createRandomGraph(rand.New(rand.NewSource(7)), 20, true)
+	h, err := contractionHierarchies.Build(g)
+	if err != nil {
+		t.Fatalf("there was a problem building the hierarchy: %v", err)
+	}
+
+	// Test
+	var buffer bytes.Buffer
+	n, err := h.WriteTo(&buffer)
+	if err != nil || n != int64(buffer.Len()) {
+		t.Fatalf("expected to write %v bytes without error; received %v, %v", buffer.Len(), n, err)
+	}
+	written := bytes.Clone(buffer.Bytes())
+
+	loaded, err := contractionHierarchies.Read(&buffer, g)
+	if err != nil {
+		t.Fatalf("there was a problem reading the hierarchy: %v", err)
+	}
+
+	if loaded.Shortcuts() != h.Shortcuts() {
+		t.Errorf("expected %v shortcuts; received %v", h.Shortcuts(), loaded.Shortcuts())
+	}
+	checkAgainstDjikstra(t, g, loaded, 20)
+
+	// Writing again gives the same bytes
+	var again bytes.Buffer
+	if _, err := loaded.WriteTo(&again); err != nil || !bytes.Equal(again.Bytes(), written) {
+		t.Errorf("expected the loaded hierarchy to be written the same way (error %v)", err)
+	}
+}
+
+/*
+TestRead1
+Description:
+
+	Tests that Read rejects other format versions and inconsistent hierarchies.
+*/
+func TestRead1(t *testing.T) {
+	// Setup
+	type fileArc struct {
+		From, To, Middle int64
+		Weight           float64
+		Shortcut         bool
+	}
+	type hierarchyFile struct {
+		Version int
+		Order   []int64
+		Arcs    []fileArc
+	}
+
+	encode := func(file hierarchyFile) *bytes.Buffer {
+		var buffer bytes.Buffer
+		if err := gob.NewEncoder(&buffer).Encode(file); err != nil {
+			t.Fatalf("there was a problem encoding the test data: %v", err)
+		}
+		return &buffer
+	}
+
+	// Test
+	_, err := contractionHierarchies.Read(encode(hierarchyFile{Version: 99, Order: []int64{0}}), nil)
+	if !errors.As(err, &contractionHierarchies.UnsupportedVersionError{}) {
+		t.Errorf("expected an UnsupportedVersionError; received %v", err)
+	}
+
+	_, err = contractionHierarchies.Read(encode(hierarchyFile{
+		Version: 1,
+		Order:   []int64{0, 1},
+		Arcs:    []fileArc{{From: 0, To: 1, Middle: 2, Weight: 2.0, Shortcut: true}},
+	}), nil)
+	if !errors.As(err, &contractionHierarchies.CorruptHierarchyError{}) {
+		t.Errorf("expected a CorruptHierarchyError; received %v", err)
+	}
+
+	// Shortcuts that skip each other would never finish unpacking
+	_, err = contractionHierarchies.Read(encode(hierarchyFile{
+		Version: 1,
+		Order:   []int64{0, 1, 2},
+		Arcs: []fileArc{
+			{From: 0, To: 2, Middle: 1, Weight: 2.0, Shortcut: true},
+			{From: 0, To: 1, Middle: 2, Weight: 1.0, Shortcut: true},
+			{From: 1, To: 2, Weight: 1.0},
+			{From: 2, To: 1, Weight: 1.0},
+		},
+	}), nil)
+	if !errors.As(err, &contractionHierarchies.CorruptHierarchyError{}) {
+		t.Errorf("expected a CorruptHierarchyError for cyclic shortcuts; received %v", err)
+	}
+
+	// Without a graph, plans use simple nodes
+	h, err := contractionHierarchies.Read(encode(hierarchyFile{
+		Version: 1,
+		Order:   []int64{0, 1},
+		Arcs:    []fileArc{{From: 0, To: 1, Weight: 2.0}},
+	}), nil)
+	if err != nil {
+		t.Fatalf("there was a problem reading the hierarchy: %v", err)
+	}
+
+	p, err := h.FindPlan(0, 1)
+	if err != nil || p.CostToGo != 2.0 || p.Sequence[1].ID() != 1 {
+		t.Errorf("expected a plan from 0 to 1 that costs 2; received %v, %v", p, err)
+	}
+}
@@ -0,0 +1,257 @@
+package dStarLite_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/dStarLite"
+	"github.com/GraphPathPlanning.go/testing/fixtures"
+	"gonum.org/v1/gonum/mat"
+	"testing"
+)
+
+/*
+planner_test.go
+Description:
+
+	Tests the D* Lite planner.
+*/
+
+/*
+TestPlanner_Plan1
+Description:
+
+	Tests that the first call to Plan finds an optimal plan.
+*/
+func TestPlanner_Plan1(t *testing.T) {
+	// Setup
+	n := 10
+	g := fixtures.LatticeGraph(n)
+	p := dStarLite.NewPlanner(fixtures.Euclidean)
+
+	// Test
+	plan, err := p.Plan(context.Background(), g, 0, int64(n*n-1))
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	fixtures.CheckAgainstDjikstra(t, g, plan, 0, int64(n*n-1))
+}
+
+/*
+TestPlanner_UpdateEdge1
+Description:
+
+	Tests that the planner repairs its plan when the edges on the
+	current plan are removed, one at a time, while the start moves
+	along the plan (like a robot discovering blocked edges while driving).
+*/
+func TestPlanner_UpdateEdge1(t *testing.T) {
+	// Setup
+	n := 10
+	g := fixtures.LatticeGraph(n)
+	goal := int64(n*n - 1)
+	p := dStarLite.NewPlanner(fixtures.Euclidean)
+
+	start := int64(0)
+	plan, err := p.Plan(context.Background(), g, start, goal)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	// Test
+	for step := 0; step < 6; step++ {
+		// Drive one step, then discover that the next edge is blocked
+		ids := plan.NodeIDs()
+		start = ids[1]
+		if len(ids) < 3 {
+			break
+		}
+		g.RemoveEdge(ids[1], ids[2])
+		p.UpdateEdge(ids[1], ids[2])
+
+		plan, err = p.Plan(context.Background(), g, start, goal)
+		if err != nil {
+			t.Fatalf("there was a problem repairing the plan: %v", err)
+		}
+
+		fixtures.CheckAgainstDjikstra(t, g, plan, start, goal)
+	}
+}
+
+/*
+TestPlanner_UpdateEdge2
+Description:
+
+	Tests that the planner notices when edges become cheaper
+	and when the goal becomes unreachable (and reachable again).
+*/
+func TestPlanner_UpdateEdge2(t *testing.T) {
+	// Setup
+	g := position_graph.New()
+	nodes := make([]position_graph.Node, 4)
+	for idx := range nodes {
+		nodes[idx] = g.AddNodeAt(mat.NewVecDense(2, []float64{float64(idx), 0.0}))
+	}
+	g.AddEdgeBetween(nodes[0], nodes[1])
+	g.AddEdgeBetween(nodes[1], nodes[2])
+	g.AddEdgeBetween(nodes[2], nodes[3])
+
+	p := &dStarLite.Planner{}
+	if _, err := p.Plan(context.Background(), g, 0, 3); err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	// Test: a shortcut is added
+	g.AddEdgeBetween(nodes[0], nodes[3])
+	if err := g.SetEdgeCost(0, 3, position_graph.DistanceCost, 2.5); err != nil {
+		t.Fatalf("there was a problem setting the edge cost: %v", err)
+	}
+	p.UpdateEdge(0, 3)
+
+	plan, err := p.Plan(context.Background(), g, 0, 3)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+	if len(plan.Sequence) != 2 || plan.CostToGo != 2.5 {
+		t.Errorf("expected the plan to use the shortcut; received %v (cost %v)", plan.NodeIDs(), plan.CostToGo)
+	}
+
+	// Test: the goal is cut off
+	g.RemoveEdge(0, 3)
+	p.UpdateEdge(0, 3)
+	g.RemoveEdge(2, 3)
+	p.UpdateEdge(2, 3)
+
+	_, err = p.Plan(context.Background(), g, 0, 3)
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+
+	// Test: the goal is reconnected
+	g.AddEdgeBetween(nodes[2], nodes[3])
+	p.UpdateEdge(2, 3)
+
+	plan, err = p.Plan(context.Background(), g, 0, 3)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+	if plan.CostToGo != 3.0 {
+		t.Errorf("expected plan cost to be 3; received %v", plan.CostToGo)
+	}
+}
+
+/*
+TestPlanner_UpdateEdge3
+Description:
+
+	Tests that the planner follows one-way edges on a directed graph
+	and that it handles the removal of a node on the plan.
+*/
+func TestPlanner_UpdateEdge3(t *testing.T) {
+	// Setup: two one-way routes from 0 to 3 (through 1 or through 2)
+	g := position_graph.NewDirected()
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 2.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 0.0}))
+	g.AddEdgeBetween(n0, n1)
+	g.AddEdgeBetween(n1, n3)
+	g.AddEdgeBetween(n0, n2)
+	g.AddEdgeBetween(n2, n3)
+	g.AddEdgeBetween(n3, n0)
+
+	p := dStarLite.NewPlanner(fixtures.Euclidean)
+	plan, err := p.Plan(context.Background(), g, 0, 3)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+	fixtures.CheckAgainstDjikstra(t, g, plan, 0, 3)
+
+	// Test
+	g.RemoveNode(n1.ID())
+	p.UpdateEdge(n0.ID(), n1.ID())
+	p.UpdateEdge(n1.ID(), n3.ID())
+
+	plan, err = p.Plan(context.Background(), g, 0, 3)
+	if err != nil {
+		t.Fatalf("there was a problem repairing the plan: %v", err)
+	}
+	fixtures.CheckAgainstDjikstra(t, g, plan, 0, 3)
+
+	if plan.NodeIDs()[1] != n2.ID() {
+		t.Errorf("expected the plan to go through node %v; received %v", n2.ID(), plan.NodeIDs())
+	}
+}
+
+/*
+TestPlanner_Plan2
+Description:
+
+	Tests that a search stopped by the budget can be continued
+	by a later call to Plan.
+*/
+func TestPlanner_Plan2(t *testing.T) {
+	// Setup
+	n := 10
+	g := fixtures.LatticeGraph(n)
+	p := &dStarLite.Planner{Budget: planning.Budget{MaxExpansions: 5}}
+
+	// Test
+	_, err := p.Plan(context.Background(), g, 0, int64(n*n-1))
+	var budgetErr gppErrors.SearchBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected a SearchBudgetExceeded error; received %v", err)
+	}
+
+	p.Budget = planning.Budget{}
+	plan, err := p.Plan(context.Background(), g, 0, int64(n*n-1))
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	fixtures.CheckAgainstDjikstra(t, g, plan, 0, int64(n*n-1))
+}
+
+/*
+TestPlanner_Reset1
+Description:
+
+	Tests that the planner rejects a graph other than the one it is
+	bound to, until Reset binds it to that graph.
+*/
+func TestPlanner_Reset1(t *testing.T) {
+	// Setup
+	g := fixtures.LatticeGraph(10)
+	h := fixtures.LatticeGraph(4)
+	p := dStarLite.NewPlanner(fixtures.Euclidean)
+
+	if _, err := p.Plan(context.Background(), g, 0, 99); err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	// Test: the planner is still bound to g
+	if _, err := p.Plan(context.Background(), h, 0, 15); !errors.As(err, &gppErrors.UnsupportedGraph{}) {
+		t.Errorf("expected an UnsupportedGraph error; received %v", err)
+	}
+
+	plan, err := p.Plan(context.Background(), g, 0, 99)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+	fixtures.CheckAgainstDjikstra(t, g, plan, 0, 99)
+
+	// Test: after Reset, the planner searches h
+	p.Reset(h)
+	if _, err := p.Plan(context.Background(), h, 0, 99); !errors.As(err, &gppErrors.GoalNodeNotFound{}) {
+		t.Errorf("expected a GoalNodeNotFound error; received %v", err)
+	}
+
+	plan, err = p.Plan(context.Background(), h, 0, 15)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+	fixtures.CheckAgainstDjikstra(t, h, plan, 0, 15)
+}
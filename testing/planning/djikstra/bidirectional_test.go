@@ -0,0 +1,155 @@
+package djikstra_test
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"testing"
+)
+
+/*
+bidirectional_test.go
+Description:
+
+	Tests the bidirectional version of Djikstra's algorithm.
+*/
+
+/*
+TestBidirectional_FindPlanBidirectional1
+Description:
+
+	Tests that the bidirectional search finds plans with the same cost
+	as the unidirectional search between every pair of nodes in the
+	README graph, and that each plan is a connected path.
+*/
+func TestBidirectional_FindPlanBidirectional1(t *testing.T) {
+	// Setup
+	g := CreateREADMEGraph()
+	nNodes := int64(g.Nodes().Len())
+
+	// Test
+	for start := int64(0); start < nNodes; start++ {
+		for end := int64(0); end < nNodes; end++ {
+			expected, err := djikstra.FindPlan(g, start, end)
+			if err != nil {
+				t.Fatalf("there was a problem finding the plan: %v", err)
+			}
+
+			p, err := djikstra.FindPlanBidirectional(g, start, end)
+			if err != nil {
+				t.Fatalf("there was a problem finding the bidirectional plan: %v", err)
+			}
+
+			if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+				t.Errorf(
+					"expected plan from %v to %v to cost %v; received %v",
+					start, end, expected.CostToGo, p.CostToGo,
+				)
+			}
+
+			ids := p.NodeIDs()
+			if ids[0] != start || ids[len(ids)-1] != end {
+				t.Errorf("expected plan to go from %v to %v; received %v", start, end, ids)
+			}
+
+			total := 0.0
+			for idx := 0; idx+1 < len(ids); idx++ {
+				if !g.HasEdgeBetween(ids[idx], ids[idx+1]) {
+					t.Errorf("plan %v uses an edge that does not exist", ids)
+				}
+				total += p.EdgeCosts[idx]
+			}
+
+			if math.Abs(total-p.CostToGo) > 1e-9 {
+				t.Errorf("expected edge costs of %v to add up to %v; received %v", ids, p.CostToGo, total)
+			}
+		}
+	}
+}
+
+/*
+TestBidirectional_FindPlanBidirectional2
+Description:
+
+	Tests that the backward search follows one-way edges in reverse.
+*/
+func TestBidirectional_FindPlanBidirectional2(t *testing.T) {
+	// Setup
+	g := position_graph.NewDirected()
+	nodes := make([]position_graph.Node, 5)
+	for idx := range nodes {
+		nodes[idx] = g.AddNodeAt(mat.NewVecDense(2, []float64{float64(idx), 0.0}))
+	}
+
+	// A one-way chain 0 -> 1 -> 2 -> 3 -> 4 and a one-way shortcut 4 -> 0
+	for idx := 0; idx+1 < len(nodes); idx++ {
+		g.AddEdgeBetween(nodes[idx], nodes[idx+1])
+	}
+	g.AddEdgeBetween(nodes[4], nodes[0])
+
+	// Test
+	p, err := djikstra.FindPlanBidirectional(g, 0, 4)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p.Sequence) != 5 || p.CostToGo != 4.0 {
+		t.Errorf("expected the plan to follow the chain; received %v (cost %v)", p.NodeIDs(), p.CostToGo)
+	}
+
+	p, err = djikstra.FindPlanBidirectional(g, 4, 0)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p.Sequence) != 2 {
+		t.Errorf("expected the plan to use the shortcut; received %v", p.NodeIDs())
+	}
+}
+
+/*
+TestBidirectional_FindPlanBidirectional3
+Description:
+
+	Tests that the bidirectional search returns a NoPathFound error
+	when the end cannot be reached.
+*/
+func TestBidirectional_FindPlanBidirectional3(t *testing.T) {
+	// Setup
+	g := CreateLatticeGraph(5)
+	isolated := g.AddNodeAt(mat.NewVecDense(2, []float64{100.0, 100.0}))
+
+	// Test
+	_, err := djikstra.FindPlanBidirectional(g, 0, isolated.ID())
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+}
+
+/*
+TestBidirectional_FindPlanBidirectional4
+Description:
+
+	Tests the bidirectional search on a larger graph with many cycles.
+*/
+func TestBidirectional_FindPlanBidirectional4(t *testing.T) {
+	// Setup
+	n := 30
+	g := CreateLatticeGraph(n)
+
+	// Test
+	p, err := djikstra.FindPlanBidirectional(g, 0, int64(n*n-1))
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if math.Abs(p.CostToGo-float64(2*(n-1))) > 1e-6 {
+		t.Errorf("expected plan cost to be %v; received %v", 2*(n-1), p.CostToGo)
+	}
+
+	if len(p.Sequence) != 2*n-1 {
+		t.Errorf("expected %v nodes in the plan; found %v", 2*n-1, len(p.Sequence))
+	}
+}
@@ -1,10 +1,14 @@
 package djikstra_test
 
 import (
+	"context"
+	"errors"
 	"github.com/GraphPathPlanning.go/gppErrors"
 	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
 	"github.com/GraphPathPlanning.go/planning/djikstra"
 	"gonum.org/v1/gonum/mat"
+	"math"
 	"testing"
 )
 
@@ -29,7 +33,8 @@ func TestPlan_FindPlan1(t *testing.T) {
 
 	g.AddEdgeBetween(n1, n2)
 
-	// Apply Plan method
+	// Apply Plan method (the djikstra.Plan alias still names its plans)
+	var p1 *djikstra.Plan
 	p1, err := djikstra.FindPlan(g, n1.ID(), n2.ID())
 	if err != nil {
 		t.Errorf("there was a problem finding the plan: %v", err)
@@ -183,3 +188,231 @@ func TestPlan_FindPlan3(t *testing.T) {
 		}
 	}
 }
+
+/*
+CreateLatticeGraph
+Description:
+
+	Creates a square lattice graph with n nodes on each side,
+	where every node is connected to its horizontal and vertical
+	neighbors. The graph contains many cycles.
+*/
+func CreateLatticeGraph(n int) *position_graph.PositionGraph {
+	// Constants
+	g := position_graph.New()
+
+	// Algorithm
+	nodes := make([]position_graph.Node, n*n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			nodes[row*n+col] = g.AddNodeAt(
+				mat.NewVecDense(2, []float64{float64(col), float64(row)}),
+			)
+		}
+	}
+
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			if col+1 < n {
+				g.AddEdgeBetween(nodes[row*n+col], nodes[row*n+col+1])
+			}
+			if row+1 < n {
+				g.AddEdgeBetween(nodes[row*n+col], nodes[(row+1)*n+col])
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+TestPlan_FindPlan4
+Description:
+
+	Tests that FindPlan terminates quickly on a graph with many cycles
+	(a 30x30 lattice) and that the plan it returns is optimal.
+*/
+func TestPlan_FindPlan4(t *testing.T) {
+	// Setup
+	n := 30
+	g := CreateLatticeGraph(n)
+
+	// Algorithm
+	p1, err := djikstra.FindPlan(g, 0, int64(n*n-1))
+	if err != nil {
+		t.Errorf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p1.Sequence) != 2*n-1 {
+		t.Errorf(
+			"expected %v nodes in the plan; found %v",
+			2*n-1,
+			len(p1.Sequence),
+		)
+	}
+
+	if math.Abs(p1.CostToGo-float64(2*(n-1))) > 1e-6 {
+		t.Errorf(
+			"expected plan cost to be %v; received %v",
+			2*(n-1),
+			p1.CostToGo,
+		)
+	}
+}
+
+/*
+TestPlan_FindPlanWithContext1
+Description:
+
+	Tests that FindPlanWithContext stops with a SearchCancelled error
+	(that wraps the context's error) when the context is already cancelled.
+*/
+func TestPlan_FindPlanWithContext1(t *testing.T) {
+	// Setup
+	g := CreateLatticeGraph(5)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Algorithm
+	_, err := djikstra.FindPlanWithContext(ctx, g, 0, 24, planning.Budget{})
+
+	var cancelled gppErrors.SearchCancelled
+	if !errors.As(err, &cancelled) {
+		t.Errorf("expected a SearchCancelled error; received %v", err)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlanWithContext2
+Description:
+
+	Tests that FindPlanWithContext stops with a SearchBudgetExceeded error
+	after the maximum number of expansions when the goal is unreachable.
+*/
+func TestPlan_FindPlanWithContext2(t *testing.T) {
+	// Setup
+	g := CreateLatticeGraph(10)
+	isolated := g.AddNodeAt(mat.NewVecDense(2, []float64{100.0, 100.0}))
+	budget := planning.Budget{MaxExpansions: 20}
+
+	// Algorithm
+	_, err := djikstra.FindPlanWithContext(context.Background(), g, 0, isolated.ID(), budget)
+
+	var exceeded gppErrors.SearchBudgetExceeded
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected a SearchBudgetExceeded error; received %v", err)
+	}
+
+	if exceeded.Budget != planning.MaxExpansionsBudget {
+		t.Errorf("expected the %v budget to run out; received %v", planning.MaxExpansionsBudget, exceeded.Budget)
+	}
+
+	if exceeded.Statistics.Expansions != budget.MaxExpansions {
+		t.Errorf(
+			"expected %v expansions in the statistics; received %v",
+			budget.MaxExpansions,
+			exceeded.Statistics.Expansions,
+		)
+	}
+
+	if exceeded.Statistics.MaxHeapSize == 0 {
+		t.Errorf("expected the statistics to record the heap size")
+	}
+}
+
+/*
+TestPlan_FindPlanWithContext3
+Description:
+
+	Tests that FindPlanWithContext stops with a SearchBudgetExceeded error
+	when the goal is further away than the maximum cost.
+*/
+func TestPlan_FindPlanWithContext3(t *testing.T) {
+	// Setup
+	g := CreateLatticeGraph(10)
+	budget := planning.Budget{MaxCost: 5.0}
+
+	// Algorithm
+	_, err := djikstra.FindPlanWithContext(context.Background(), g, 0, 99, budget)
+
+	var exceeded gppErrors.SearchBudgetExceeded
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected a SearchBudgetExceeded error; received %v", err)
+	}
+
+	if exceeded.Budget != planning.MaxCostBudget {
+		t.Errorf("expected the %v budget to run out; received %v", planning.MaxCostBudget, exceeded.Budget)
+	}
+
+	// A budget that is large enough should not get in the way.
+	budget.MaxCost = 18.0
+	if _, err := djikstra.FindPlanWithContext(context.Background(), g, 0, 99, budget); err != nil {
+		t.Errorf("expected a plan within the budget; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan5
+Description:
+
+	Tests that FindPlan returns a StartNodeNotFound or GoalNodeNotFound
+	error (instead of panicking) when an endpoint is not in the graph.
+*/
+func TestPlan_FindPlan5(t *testing.T) {
+	// Setup
+	g := CreateREADMEGraph()
+
+	// Test
+	_, err := djikstra.FindPlan(g, 100, 0)
+	if err != (gppErrors.StartNodeNotFound{ID: 100}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+
+	_, err = djikstra.FindPlan(g, 0, 200)
+	if err != (gppErrors.GoalNodeNotFound{ID: 200}) {
+		t.Errorf("expected a GoalNodeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan6
+Description:
+
+	Tests that FindPlan honors one-way edges in a directed position graph:
+	the short way from node 0 to node 2 is a one-way edge pointing the
+	wrong way, so the plan must go around through node 1.
+*/
+func TestPlan_FindPlan6(t *testing.T) {
+	// Setup
+	g := position_graph.NewDirected()
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 1.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 0.0}))
+
+	g.AddEdgeBetween(n2, n0)
+	g.AddEdgeBetween(n0, n1)
+	g.AddEdgeBetween(n1, n2)
+
+	// Test
+	p1, err := djikstra.FindPlan(g, n0.ID(), n2.ID())
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p1.Sequence) != 3 || p1.Sequence[1].ID() != n1.ID() {
+		t.Errorf("expected the plan to go through node 1; received %v", p1.NodeIDs())
+	}
+
+	p2, err := djikstra.FindPlan(g, n2.ID(), n0.ID())
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if len(p2.Sequence) != 2 {
+		t.Errorf("expected the plan to use the one-way edge; received %v", p2.NodeIDs())
+	}
+}
@@ -0,0 +1,60 @@
+package planning_test
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph/simple"
+	"testing"
+)
+
+/*
+endpoints_test.go
+Description:
+
+	Tests the checks that planners run on their start and goal nodes.
+*/
+
+/*
+TestEndpoints_CheckEndpoints1
+Description:
+
+	Tests CheckEndpoints on a position graph.
+*/
+func TestEndpoints_CheckEndpoints1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPlanner1()
+
+	// Test
+	if err := planning.CheckEndpoints(g, 0, 3); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := planning.CheckEndpoints(g, 100, 3); err != (gppErrors.StartNodeNotFound{ID: 100}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+
+	if err := planning.CheckEndpoints(g, 0, 100); err != (gppErrors.GoalNodeNotFound{ID: 100}) {
+		t.Errorf("expected a GoalNodeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestEndpoints_HasNode1
+Description:
+
+	Tests HasNode on a gonum graph (which returns nil for missing nodes).
+*/
+func TestEndpoints_HasNode1(t *testing.T) {
+	// Setup
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	g.AddNode(simple.Node(1))
+
+	// Test
+	if !planning.HasNode(g, 1) {
+		t.Errorf("expected node 1 to be in the graph")
+	}
+
+	if planning.HasNode(g, 2) {
+		t.Errorf("did not expect node 2 to be in the graph")
+	}
+}
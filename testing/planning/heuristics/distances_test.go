@@ -0,0 +1,92 @@
+package heuristics_test
+
+import (
+	"github.com/GraphPathPlanning.go/planning/heuristics"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"testing"
+)
+
+/*
+distances_test.go
+Description:
+
+	Tests the distances between positions used by the heuristics.
+*/
+
+/*
+TestDistances1
+Description:
+
+	Tests each distance between two positions in 2D and in 3D.
+*/
+func TestDistances1(t *testing.T) {
+	// Setup
+	a2, b2 := mat.NewVecDense(2, []float64{0, 0}), mat.NewVecDense(2, []float64{-3, 4})
+	a3, b3 := mat.NewVecDense(3, []float64{1, 1, 1}), mat.NewVecDense(3, []float64{2, 3, 4})
+
+	// Test
+	for _, tc := range []struct {
+		name     string
+		received float64
+		expected float64
+	}{
+		{"Euclidean 2D", heuristics.EuclideanDistance(a2, b2), 5},
+		{"Manhattan 2D", heuristics.ManhattanDistance(a2, b2), 7},
+		{"Chebyshev 2D", heuristics.ChebyshevDistance(a2, b2), 4},
+		{"Octile 2D", heuristics.OctileDistance(a2, b2), 3*math.Sqrt2 + 1},
+		{"Euclidean 3D", heuristics.EuclideanDistance(a3, b3), math.Sqrt(14)},
+		{"Manhattan 3D", heuristics.ManhattanDistance(a3, b3), 6},
+		{"Chebyshev 3D", heuristics.ChebyshevDistance(a3, b3), 3},
+		{"Octile 3D", heuristics.OctileDistance(a3, b3), math.Sqrt(3) + math.Sqrt2 + 1},
+	} {
+		if math.Abs(tc.received-tc.expected) > 1e-12 {
+			t.Errorf("expected %v distance %v; received %v", tc.name, tc.expected, tc.received)
+		}
+	}
+}
+
+/*
+TestGreatCircleDistance1
+Description:
+
+	Tests the great-circle distance along the equator, to the pole
+	and between Paris and London.
+*/
+func TestGreatCircleDistance1(t *testing.T) {
+	// Setup
+	origin := mat.NewVecDense(2, []float64{0, 0})
+	quarter := math.Pi / 2 * heuristics.EarthRadius
+
+	// Test
+	if d := heuristics.GreatCircleDistance(origin, mat.NewVecDense(2, []float64{0, 90}), heuristics.EarthRadius); math.Abs(d-quarter) > 1e-6 {
+		t.Errorf("expected a quarter of the equator (%v); received %v", quarter, d)
+	}
+
+	if d := heuristics.GreatCircleDistance(origin, mat.NewVecDense(2, []float64{90, 0}), heuristics.EarthRadius); math.Abs(d-quarter) > 1e-6 {
+		t.Errorf("expected a quarter of a meridian (%v); received %v", quarter, d)
+	}
+
+	// Paris to London is about 344 km
+	paris := mat.NewVecDense(2, []float64{48.8566, 2.3522})
+	london := mat.NewVecDense(2, []float64{51.5074, -0.1278})
+	if d := heuristics.GreatCircleDistance(paris, london, heuristics.EarthRadius); math.Abs(d-343.5e3) > 1e3 {
+		t.Errorf("expected about 344 km from Paris to London; received %v m", d)
+	}
+}
+
+/*
+TestDistances2
+Description:
+
+	Tests that positions with different dimensions are rejected.
+*/
+func TestDistances2(t *testing.T) {
+	defer func() {
+		if r := recover(); r != mat.ErrShape {
+			t.Errorf("expected a panic with mat.ErrShape; received %v", r)
+		}
+	}()
+
+	heuristics.EuclideanDistance(mat.NewVecDense(2, nil), mat.NewVecDense(3, nil))
+}
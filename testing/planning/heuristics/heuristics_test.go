@@ -0,0 +1,246 @@
+package heuristics_test
+
+import (
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"github.com/GraphPathPlanning.go/planning/heuristics"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"testing"
+)
+
+/*
+heuristics_test.go
+Description:
+
+	Tests the heuristics for position graphs and the checks
+	of admissibility and consistency.
+*/
+
+/*
+createPositionGrid
+Description:
+
+	Creates an n x n grid of nodes in a PositionGraph, where node x*n + y
+	is at position (x, y). Neighbors along the axes are always connected,
+	and diagonal neighbors are connected if diagonal is true. Every edge
+	costs its length.
+*/
+func createPositionGrid(n int64, diagonal bool) *position_graph.PositionGraph {
+	g := position_graph.New()
+	nodes := make([]position_graph.Node, 0, n*n)
+	for x := int64(0); x < n; x++ {
+		for y := int64(0); y < n; y++ {
+			nodes = append(nodes, g.AddNodeAt(mat.NewVecDense(2, []float64{float64(x), float64(y)})))
+		}
+	}
+
+	for x := int64(0); x < n; x++ {
+		for y := int64(0); y < n; y++ {
+			if x+1 < n {
+				g.AddEdgeBetween(nodes[n*x+y], nodes[n*(x+1)+y])
+			}
+			if y+1 < n {
+				g.AddEdgeBetween(nodes[n*x+y], nodes[n*x+y+1])
+			}
+			if diagonal && x+1 < n && y+1 < n {
+				g.AddEdgeBetween(nodes[n*x+y], nodes[n*(x+1)+y+1])
+				g.AddEdgeBetween(nodes[n*x+y+1], nodes[n*(x+1)+y])
+			}
+		}
+	}
+
+	return g
+}
+
+/*
+TestHeuristics1
+Description:
+
+	Tests that A* finds optimal plans with the Euclidean, octile and
+	scaled heuristics on an 8-connected grid, and that the octile
+	heuristic is exact on a grid without obstacles.
+*/
+func TestHeuristics1(t *testing.T) {
+	// Setup
+	const n = 8
+	g := createPositionGrid(n, true)
+	start, goal := int64(0), int64(n*n-3)
+
+	expected, err := djikstra.FindPlan(g, start, goal)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	// Test
+	for name, h := range map[string]func(*aStar.PlanningNode) float64{
+		"Euclidean": heuristics.Euclidean(goal),
+		"Octile":    heuristics.Octile(goal),
+		"Scaled":    heuristics.Scaled(heuristics.Octile(goal), 0.5),
+	} {
+		p, err := aStar.FindPlan(g, start, goal, h)
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan with the %v heuristic: %v", name, err)
+		}
+
+		if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+			t.Errorf("expected the %v heuristic to find a plan that costs %v; received %v", name, expected.CostToGo, p.CostToGo)
+		}
+	}
+
+	octile := heuristics.Octile(goal)(&aStar.PlanningNode{Graph: g, CurrentGraphNode: g.Node(start)})
+	if math.Abs(octile-expected.CostToGo) > 1e-9 {
+		t.Errorf("expected the octile estimate to be exact (%v); received %v", expected.CostToGo, octile)
+	}
+}
+
+/*
+TestHeuristics2
+Description:
+
+	Tests that the heuristics estimate 0, instead of panicking, when the
+	positions of a node and its goal cannot be compared.
+*/
+func TestHeuristics2(t *testing.T) {
+	// Setup
+	g := position_graph.New()
+	flat := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	high := g.AddNodeAt(mat.NewVecDense(3, []float64{1.0, 1.0, 1.0}))
+	line := g.AddNodeAt(mat.NewVecDense(1, []float64{2.0}))
+	pn := &aStar.PlanningNode{Graph: g, CurrentGraphNode: g.Node(flat.ID())}
+
+	// Test
+	for name, h := range map[string]func(*aStar.PlanningNode) float64{
+		"Euclidean": heuristics.Euclidean(high.ID()),
+		"Octile":    heuristics.Octile(line.ID()),
+		"Haversine": heuristics.Haversine(line.ID()),
+	} {
+		if estimate := h(pn); estimate != 0.0 {
+			t.Errorf("expected the %v heuristic to estimate 0; received %v", name, estimate)
+		}
+	}
+
+	lineNode := &aStar.PlanningNode{Graph: g, CurrentGraphNode: g.Node(line.ID())}
+	lineGoal := g.AddNodeAt(mat.NewVecDense(1, []float64{5.0}))
+	if estimate := heuristics.Haversine(lineGoal.ID())(lineNode); estimate != 0.0 {
+		t.Errorf("expected the Haversine heuristic to estimate 0 between 1D positions; received %v", estimate)
+	}
+
+	nearest := heuristics.ToNearestGoal(heuristics.EuclideanDistance)
+	if estimate := nearest.Estimate(g, g.Node(flat.ID()), []int64{high.ID(), line.ID()}); estimate != 0.0 {
+		t.Errorf("expected the goal-aware heuristic to estimate 0; received %v", estimate)
+	}
+}
+
+/*
+TestCheckAdmissible1
+Description:
+
+	Tests which heuristics are admissible on 4- and 8-connected grids.
+*/
+func TestCheckAdmissible1(t *testing.T) {
+	// Setup
+	grid4, grid8 := createPositionGrid(5, false), createPositionGrid(5, true)
+	goal := int64(12)
+
+	// Test
+	for _, tc := range []struct {
+		name       string
+		g          *position_graph.PositionGraph
+		h          func(*aStar.PlanningNode) float64
+		admissible bool
+	}{
+		{"Manhattan on a 4-connected grid", grid4, heuristics.Manhattan(goal), true},
+		{"Manhattan on an 8-connected grid", grid8, heuristics.Manhattan(goal), false},
+		{"Octile on an 8-connected grid", grid8, heuristics.Octile(goal), true},
+		{"Chebyshev on an 8-connected grid", grid8, heuristics.Chebyshev(goal), true},
+		{"Euclidean on an 8-connected grid", grid8, heuristics.Euclidean(goal), true},
+		{"inflated Euclidean on an 8-connected grid", grid8, heuristics.Scaled(heuristics.Euclidean(goal), 1.5), false},
+	} {
+		err := heuristics.CheckAdmissible(tc.g, goal, tc.h)
+		if tc.admissible && err != nil {
+			t.Errorf("expected %v to be admissible; received %v", tc.name, err)
+		}
+		if !tc.admissible && !errors.As(err, &heuristics.InadmissibleError{}) {
+			t.Errorf("expected %v to be inadmissible; received %v", tc.name, err)
+		}
+
+		if tc.admissible {
+			if err := heuristics.CheckConsistent(tc.g, goal, tc.h); err != nil {
+				t.Errorf("expected %v to be consistent; received %v", tc.name, err)
+			}
+		}
+	}
+
+	if err := heuristics.CheckAdmissible(grid4, 42, heuristics.Euclidean(goal)); !errors.As(err, &gppErrors.GoalNodeNotFound{}) {
+		t.Errorf("expected a GoalNodeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestCheckConsistent1
+Description:
+
+	Tests a heuristic that is admissible but not consistent,
+	and one that does not vanish at the goal.
+*/
+func TestCheckConsistent1(t *testing.T) {
+	// Setup: the path 0 - 1 - 2 with edges of cost 1
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(0), simple.Node(1), 1.0))
+	g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(1), simple.Node(2), 1.0))
+
+	estimates := map[int64]float64{0: 2.0, 1: 0.0, 2: 0.0}
+	h := func(pn *aStar.PlanningNode) float64 { return estimates[pn.CurrentGraphNode.ID()] }
+
+	// Test
+	if err := heuristics.CheckAdmissible(g, 2, h); err != nil {
+		t.Errorf("expected the heuristic to be admissible; received %v", err)
+	}
+
+	var inconsistent heuristics.InconsistentError
+	if err := heuristics.CheckConsistent(g, 2, h); !errors.As(err, &inconsistent) || inconsistent.From != 0 || inconsistent.To != 1 {
+		t.Errorf("expected the edge from 0 to 1 to be inconsistent; received %v", err)
+	}
+
+	estimates = map[int64]float64{0: 2.0, 1: 1.0, 2: 0.5}
+	if err := heuristics.CheckConsistent(g, 2, h); !errors.As(err, &heuristics.InadmissibleError{}) {
+		t.Errorf("expected an estimate above 0 at the goal to be rejected; received %v", err)
+	}
+
+	// Nodes without positions get an estimate of 0
+	if estimate := heuristics.Euclidean(2)(&aStar.PlanningNode{Graph: g, CurrentGraphNode: g.Node(0)}); estimate != 0.0 {
+		t.Errorf("expected an estimate of 0 without positions; received %v", estimate)
+	}
+}
+
+/*
+TestToNearestGoal1
+Description:
+
+	Tests that one goal-aware heuristic estimates the distance
+	to the nearest goal and finds optimal plans to several goals.
+*/
+func TestToNearestGoal1(t *testing.T) {
+	// Setup
+	g := createPositionGrid(6, true)
+	h := heuristics.ToNearestGoal(heuristics.OctileDistance)
+
+	// Test
+	if estimate := h.Estimate(g, g.Node(0), []int64{35, 3}); math.Abs(estimate-3.0) > 1e-12 {
+		t.Errorf("expected the estimate to the nearest goal (3); received %v", estimate)
+	}
+
+	p, err := aStar.FindPlanToGoals(g, 0, []int64{35, 14}, h)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	if last := p.Sequence[len(p.Sequence)-1].ID(); last != 14 || math.Abs(p.CostToGo-2*math.Sqrt2) > 1e-9 {
+		t.Errorf("expected a plan to node 14 that costs 2*sqrt(2); received a plan to %v that costs %v", last, p.CostToGo)
+	}
+}
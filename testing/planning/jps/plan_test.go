@@ -0,0 +1,209 @@
+package jps_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"github.com/GraphPathPlanning.go/planning/jps"
+	"github.com/GraphPathPlanning.go/testing/fixtures"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+plan_test.go
+Description:
+
+	Tests the Jump Point Search planner.
+*/
+
+/*
+octileHeuristicTo
+Description:
+
+	Returns an A* heuristic that estimates the cost of moving
+	to the cell of the node with ID target on the grid g.
+*/
+func octileHeuristicTo(g *grid_graph.Grid, target int64) func(*aStar.PlanningNode) float64 {
+	targetCell := g.Cell(target)
+	return func(pn *aStar.PlanningNode) float64 {
+		cell := g.Cell(pn.NodeID())
+		dx := math.Abs(float64(cell[0] - targetCell[0]))
+		dy := math.Abs(float64(cell[1] - targetCell[1]))
+		return math.Max(dx, dy) - math.Min(dx, dy) + math.Sqrt2*math.Min(dx, dy)
+	}
+}
+
+/*
+TestPlan_FindPlan1
+Description:
+
+	Tests that JPS finds plans with the same cost as A* on random grids,
+	for every diagonal policy.
+*/
+func TestPlan_FindPlan1(t *testing.T) {
+	// Setup
+	policies := []grid_graph.DiagonalPolicy{
+		grid_graph.NoCornerCutting,
+		grid_graph.AllowCornerCutting,
+		grid_graph.AllowSqueezing,
+	}
+	rng := rand.New(rand.NewSource(15))
+
+	// Test
+	for _, policy := range policies {
+		for trial := 0; trial < 40; trial++ {
+			g := fixtures.RandomGrid(rng, 25, 20, 0.3, false)
+			g.Diagonal = policy
+
+			start := g.ID(rng.Intn(25), rng.Intn(20))
+			end := g.ID(rng.Intn(25), rng.Intn(20))
+			g.Unblock(g.Cell(start)...)
+			g.Unblock(g.Cell(end)...)
+
+			expected, err := aStar.FindPlan(g, start, end, octileHeuristicTo(g, end))
+			p, err2 := jps.FindPlan(g, start, end)
+
+			if (err == nil) != (err2 == nil) {
+				t.Fatalf("policy %v: expected the same error from both planners; received %v and %v", policy, err, err2)
+			}
+			if err != nil {
+				continue
+			}
+
+			if math.Abs(p.CostToGo-expected.CostToGo) > 1e-9 {
+				t.Errorf(
+					"policy %v: expected plan from %v to %v to cost %v; received %v",
+					policy, g.Cell(start), g.Cell(end), expected.CostToGo, p.CostToGo,
+				)
+			}
+			fixtures.CheckPlan(t, p, start, end, g.Weight)
+		}
+	}
+}
+
+/*
+TestPlan_FindPlan2
+Description:
+
+	Tests that JPS expands far fewer nodes than A* on a large open grid.
+*/
+func TestPlan_FindPlan2(t *testing.T) {
+	// Setup: a 200 x 200 grid with a wall in the middle
+	n := 200
+	g := grid_graph.New(n, n)
+	for y := 0; y < n-20; y++ {
+		g.Block(n/2, y)
+	}
+	start, end := g.ID(0, 0), g.ID(n-1, 0)
+
+	// Test
+	budget := planning.Budget{MaxExpansions: 100}
+	p, err := jps.FindPlanWithContext(context.Background(), g, start, end, budget)
+	if err != nil {
+		t.Fatalf("expected JPS to stay within %v expansions; received %v", budget.MaxExpansions, err)
+	}
+	fixtures.CheckPlan(t, p, start, end, g.Weight)
+
+	_, err = aStar.FindPlanWithContext(context.Background(), g, start, end, octileHeuristicTo(g, end), budget)
+	var budgetErr gppErrors.SearchBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Errorf("expected A* to need more than %v expansions; received %v", budget.MaxExpansions, err)
+	}
+}
+
+/*
+TestPlan_FindPlan3
+Description:
+
+	Tests that JPS reports unreachable ends and missing endpoints.
+*/
+func TestPlan_FindPlan3(t *testing.T) {
+	// Setup: a wall that splits the grid in two
+	g := grid_graph.New(5, 5)
+	for y := 0; y < 5; y++ {
+		g.Block(2, y)
+	}
+
+	// Test
+	_, err := jps.FindPlan(g, g.ID(0, 0), g.ID(4, 4))
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+
+	_, err = jps.FindPlan(g, g.ID(2, 2), g.ID(4, 4))
+	if err != (gppErrors.StartNodeNotFound{ID: g.ID(2, 2)}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+
+	p, err := jps.FindPlan(g, g.ID(0, 0), g.ID(0, 0))
+	if err != nil || len(p.Sequence) != 1 {
+		t.Errorf("expected a plan with one node; received %v, %v", p, err)
+	}
+}
+
+/*
+TestPlanner_Plan1
+Description:
+
+	Tests that the JPS Planner rejects graphs that are not grids.
+*/
+func TestPlanner_Plan1(t *testing.T) {
+	// Setup
+	g := position_graph.New()
+
+	// Test
+	_, err := jps.Planner{}.Plan(context.Background(), g, 0, 0)
+	if _, ok := err.(gppErrors.UnsupportedGraph); !ok {
+		t.Errorf("expected an UnsupportedGraph error; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan4
+Description:
+
+	Tests that JPS scales its plan with the grid's resolution and uniform cost,
+	and rejects grids that it cannot plan over.
+*/
+func TestPlan_FindPlan4(t *testing.T) {
+	// Setup
+	g := grid_graph.New(10, 10)
+	if err := g.SetResolution(0.5); err != nil {
+		t.Fatalf("there was a problem setting the resolution: %v", err)
+	}
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if err := g.SetCost(2.0, x, y); err != nil {
+				t.Fatalf("there was a problem setting the cost: %v", err)
+			}
+		}
+	}
+
+	// Test
+	p, err := jps.FindPlan(g, g.ID(0, 0), g.ID(9, 4))
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	expected := 0.5 * 2.0 * (5.0 + 4.0*math.Sqrt2)
+	if math.Abs(p.CostToGo-expected) > 1e-9 {
+		t.Errorf("expected plan cost %v; received %v", expected, p.CostToGo)
+	}
+
+	_ = g.SetCost(3.0, 5, 5)
+	if _, err := jps.FindPlan(g, g.ID(0, 0), g.ID(9, 4)); !errors.As(err, &gppErrors.UnsupportedGraph{}) {
+		t.Errorf("expected an UnsupportedGraph error for non-uniform costs; received %v", err)
+	}
+
+	g4 := grid_graph.New(10, 10)
+	_ = g4.SetConnectivity(grid_graph.Connect4)
+	if _, err := jps.FindPlan(g4, g4.ID(0, 0), g4.ID(9, 4)); !errors.As(err, &gppErrors.UnsupportedGraph{}) {
+		t.Errorf("expected an UnsupportedGraph error for a 4-connected grid; received %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package kShortest_test
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"github.com/GraphPathPlanning.go/planning/kShortest"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"testing"
+)
+
+/*
+alternatives_test.go
+Description:
+
+	Tests the dissimilar alternative plans found with the penalty method.
+*/
+
+/*
+createRoadGrid
+Description:
+
+	Creates a 4-connected size x size grid, which looks like a city's road network.
+*/
+func createRoadGrid(size int) *grid_graph.Grid {
+	g := grid_graph.New(size, size)
+	_ = g.SetConnectivity(grid_graph.Connect4)
+
+	return g
+}
+
+/*
+TestAlternatives_FindAlternatives1
+Description:
+
+	Tests that the alternatives start with the shortest plan, are ordered by cost,
+	and that no pair of them overlaps by more than MaxOverlap.
+*/
+func TestAlternatives_FindAlternatives1(t *testing.T) {
+	// Setup
+	g := createRoadGrid(10)
+	start, end := g.ID(0, 0), g.ID(9, 9)
+	shortest, _ := djikstra.FindPlan(g, start, end)
+
+	for _, maxOverlap := range []float64{0.3, 0.6, 0.8} {
+		options := kShortest.DefaultAlternativeOptions(3)
+		options.MaxOverlap = maxOverlap
+
+		// Test
+		alternatives, err := kShortest.FindAlternatives(context.Background(), g, start, end, options)
+		if err != nil {
+			t.Fatalf("there was a problem finding the alternatives: %v", err)
+		}
+
+		if len(alternatives) != 3 {
+			t.Fatalf("expected 3 alternatives with a maximum overlap of %v; received %v", maxOverlap, len(alternatives))
+		}
+
+		plans := make([]*planning.Plan, len(alternatives))
+		for idx, alternative := range alternatives {
+			plans[idx] = alternative.Plan
+		}
+		checkPlans(t, g, plans, start, end)
+
+		if alternatives[0].Plan.CostToGo != shortest.CostToGo {
+			t.Errorf("expected the first alternative to cost %v; received %v", shortest.CostToGo, alternatives[0].Plan.CostToGo)
+		}
+
+		for i, a := range alternatives {
+			largest := 0.0
+			for j, b := range alternatives {
+				if i != j {
+					largest = max(largest, kShortest.Overlap(g, a.Plan, b.Plan))
+				}
+			}
+
+			if largest > maxOverlap || math.Abs(largest-a.Overlap) > 1e-12 {
+				t.Errorf("expected alternative %v to overlap by %v (at most %v); received %v", i, largest, maxOverlap, a.Overlap)
+			}
+		}
+	}
+}
+
+/*
+TestAlternatives_FindAlternatives2
+Description:
+
+	Tests that the alternatives avoid the plans of Yen's algorithm that
+	differ by a single detour, and that MaxStretch rejects long alternatives.
+*/
+func TestAlternatives_FindAlternatives2(t *testing.T) {
+	// Setup: a road from node 0 to node 9 where every segment has a slightly longer detour
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for id := int64(0); id < 9; id++ {
+		detour := simple.Node(100 + id)
+		g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(id), simple.Node(id+1), 1.0))
+		g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(id), detour, 0.6))
+		g.SetWeightedEdge(g.NewWeightedEdge(detour, simple.Node(id+1), 0.6))
+	}
+
+	// Test
+	plans, err := kShortest.FindPlans(g, 0, 9, 2)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plans: %v", err)
+	}
+
+	if overlap := kShortest.Overlap(g, plans[0], plans[1]); overlap < 0.8 {
+		t.Errorf("expected the two shortest plans to overlap by more than 0.8; received %v", overlap)
+	}
+
+	alternatives, err := kShortest.FindAlternatives(context.Background(), g, 0, 9, kShortest.DefaultAlternativeOptions(3))
+	if err != nil {
+		t.Fatalf("there was a problem finding the alternatives: %v", err)
+	}
+
+	if len(alternatives) != 2 || alternatives[1].Overlap != 0.0 || math.Abs(alternatives[1].Plan.CostToGo-10.8) > 1e-9 {
+		t.Fatalf("expected the road and the plan along every detour; received %v alternatives", len(alternatives))
+	}
+
+	options := kShortest.DefaultAlternativeOptions(3)
+	options.MaxStretch = 1.1
+	alternatives, _ = kShortest.FindAlternatives(context.Background(), g, 0, 9, options)
+	if len(alternatives) != 1 {
+		t.Errorf("expected only the shortest plan to be short enough; received %v alternatives", len(alternatives))
+	}
+}
+
+/*
+TestAlternatives_FindAlternatives3
+Description:
+
+	Tests that FindAlternatives rejects out-of-range options.
+*/
+func TestAlternatives_FindAlternatives3(t *testing.T) {
+	// Setup
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(0), simple.Node(1), 1.0))
+
+	// Test
+	for name, change := range map[string]func(*kShortest.AlternativeOptions){
+		"MaxOverlap":    func(o *kShortest.AlternativeOptions) { o.MaxOverlap = 1.5 },
+		"PenaltyFactor": func(o *kShortest.AlternativeOptions) { o.PenaltyFactor = -2.0 },
+		"MaxIterations": func(o *kShortest.AlternativeOptions) { o.MaxIterations = -1 },
+		"MaxStretch":    func(o *kShortest.AlternativeOptions) { o.MaxStretch = math.NaN() },
+	} {
+		options := kShortest.DefaultAlternativeOptions(2)
+		change(&options)
+
+		_, err := kShortest.FindAlternatives(context.Background(), g, 0, 1, options)
+		if invalid, ok := err.(gppErrors.InvalidOption); !ok || invalid.Name != name {
+			t.Errorf("expected an InvalidOption error for %v; received %v", name, err)
+		}
+	}
+
+	options := kShortest.DefaultAlternativeOptions(2)
+	options.PenaltyFactor = 1.0
+	if _, err := kShortest.FindAlternatives(context.Background(), g, 0, 1, options); err == nil {
+		t.Errorf("expected a penalty factor of 1 to be rejected")
+	}
+}
+
+/*
+TestAlternatives_Overlap1
+Description:
+
+	Tests the overlap of identical, disjoint and partly shared plans.
+*/
+func TestAlternatives_Overlap1(t *testing.T) {
+	// Setup
+	g := createRoadGrid(3)
+	p1, _ := djikstra.FindPlan(g, g.ID(0, 0), g.ID(2, 0))
+	p2, _ := djikstra.FindPlan(g, g.ID(0, 1), g.ID(2, 1))
+	p3, _ := djikstra.FindPlan(g, g.ID(1, 0), g.ID(2, 0))
+
+	// Test
+	if overlap := kShortest.Overlap(g, p1, p1); overlap != 1.0 {
+		t.Errorf("expected a plan to overlap itself by 1; received %v", overlap)
+	}
+
+	if overlap := kShortest.Overlap(g, p1, p2); overlap != 0.0 {
+		t.Errorf("expected disjoint plans to overlap by 0; received %v", overlap)
+	}
+
+	if overlap := kShortest.Overlap(g, p1, p3.Reversed()); overlap != 1.0 {
+		t.Errorf("expected a plan to fully overlap a shorter plan along it; received %v", overlap)
+	}
+}
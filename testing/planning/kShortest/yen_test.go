@@ -0,0 +1,256 @@
+package kShortest_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/kShortest"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+/*
+yen_test.go
+Description:
+
+	Tests the k shortest loopless plans found with Yen's algorithm.
+*/
+
+/*
+createYenGraph
+Description:
+
+	Creates the directed example graph from the description of Yen's algorithm
+	(nodes C to H are numbered 0 to 5).
+*/
+func createYenGraph() *simple.WeightedDirectedGraph {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []struct {
+		from, to int64
+		weight   float64
+	}{
+		{0, 1, 3}, {0, 2, 2}, {1, 3, 4}, {2, 1, 1}, {2, 3, 2},
+		{2, 4, 3}, {3, 4, 2}, {3, 5, 1}, {4, 5, 2},
+	} {
+		g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(e.from), simple.Node(e.to), e.weight))
+	}
+
+	return g
+}
+
+/*
+allLooplessCosts
+Description:
+
+	Returns the sorted costs of every loopless path from start to end in g
+	(by brute force).
+*/
+func allLooplessCosts(g graph.Weighted, start, end int64) []float64 {
+	var costs []float64
+	visited := map[int64]bool{start: true}
+
+	var visit func(id int64, cost float64)
+	visit = func(id int64, cost float64) {
+		if id == end {
+			costs = append(costs, cost)
+			return
+		}
+
+		neighbors := g.From(id)
+		for neighbors.Next() {
+			next := neighbors.Node().ID()
+			if visited[next] {
+				continue
+			}
+
+			w, _ := g.Weight(id, next)
+			visited[next] = true
+			visit(next, cost+w)
+			visited[next] = false
+		}
+	}
+	visit(start, 0.0)
+
+	slices.Sort(costs)
+	return costs
+}
+
+/*
+checkPlans
+Description:
+
+	Fails the test if the plans are not distinct, loopless, ordered paths
+	from start to end through g whose edge costs add up.
+*/
+func checkPlans(t *testing.T, g graph.Weighted, plans []*planning.Plan, start, end int64) {
+	t.Helper()
+
+	seen := make(map[string]bool)
+	for idx, p := range plans {
+		ids := p.NodeIDs()
+		if ids[0] != start || ids[len(ids)-1] != end {
+			t.Errorf("expected plan to go from %v to %v; received %v", start, end, ids)
+		}
+
+		visited := make(map[int64]bool)
+		total := 0.0
+		for step, id := range ids {
+			if visited[id] {
+				t.Errorf("expected plan %v to be loopless", ids)
+			}
+			visited[id] = true
+
+			if step+1 < len(ids) {
+				w, ok := g.Weight(id, ids[step+1])
+				if !ok || math.Abs(w-p.EdgeCosts[step]) > 1e-9 {
+					t.Errorf("plan %v uses an edge that does not exist or has the wrong cost", ids)
+				}
+				total += p.EdgeCosts[step]
+			}
+		}
+
+		if math.Abs(total-p.CostToGo) > 1e-9 {
+			t.Errorf("expected edge costs of %v to add up to %v; received %v", ids, p.CostToGo, total)
+		}
+
+		if idx > 0 && p.CostToGo < plans[idx-1].CostToGo-1e-9 {
+			t.Errorf("expected plans to be ordered by cost; plan %v costs %v after %v", idx, p.CostToGo, plans[idx-1].CostToGo)
+		}
+
+		key := fmt.Sprint(ids)
+		if seen[key] {
+			t.Errorf("expected plan %v to appear only once", ids)
+		}
+		seen[key] = true
+	}
+}
+
+/*
+TestYen_FindPlans1
+Description:
+
+	Tests Yen's algorithm on the example graph from its description.
+*/
+func TestYen_FindPlans1(t *testing.T) {
+	// Setup
+	g := createYenGraph()
+
+	// Test
+	plans, err := kShortest.FindPlans(g, 0, 5, 3)
+	if err != nil {
+		t.Fatalf("there was a problem finding the plans: %v", err)
+	}
+	checkPlans(t, g, plans, 0, 5)
+
+	if len(plans) != 3 {
+		t.Fatalf("expected 3 plans; received %v", len(plans))
+	}
+
+	if !slices.Equal(plans[0].NodeIDs(), []int64{0, 2, 3, 5}) || !slices.Equal(plans[1].NodeIDs(), []int64{0, 2, 4, 5}) {
+		t.Errorf("expected the plans C-E-F-H and C-E-G-H first; received %v and %v", plans[0].NodeIDs(), plans[1].NodeIDs())
+	}
+
+	for idx, expected := range []float64{5, 7, 8} {
+		if plans[idx].CostToGo != expected {
+			t.Errorf("expected plan %v to cost %v; received %v", idx, expected, plans[idx].CostToGo)
+		}
+	}
+
+	// There are only 7 loopless paths from C to H
+	plans, _ = kShortest.FindPlans(g, 0, 5, 100)
+	if len(plans) != 7 {
+		t.Errorf("expected 7 plans; received %v", len(plans))
+	}
+}
+
+/*
+TestYen_FindPlans2
+Description:
+
+	Tests that Yen's algorithm finds the same costs as a brute-force
+	enumeration of the loopless paths of random graphs.
+*/
+func TestYen_FindPlans2(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(18))
+
+	// Test
+	for trial := 0; trial < 100; trial++ {
+		var g interface {
+			graph.Weighted
+			SetWeightedEdge(graph.WeightedEdge)
+			NewWeightedEdge(from, to graph.Node, weight float64) graph.WeightedEdge
+		}
+		if trial%2 == 0 {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}
+
+		for from := int64(0); from < 8; from++ {
+			for to := int64(0); to < 8; to++ {
+				if from != to && rng.Float64() < 0.3 {
+					g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(from), simple.Node(to), float64(1+rng.Intn(5))))
+				}
+			}
+		}
+
+		if g.Node(0) == nil || g.Node(7) == nil {
+			continue
+		}
+
+		expected := allLooplessCosts(g, 0, 7)
+		plans, err := kShortest.FindPlans(g, 0, 7, 10)
+		if len(expected) == 0 {
+			if _, ok := err.(gppErrors.NoPathFound); !ok {
+				t.Errorf("trial %v: expected a NoPathFound error; received %v", trial, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("trial %v: there was a problem finding the plans: %v", trial, err)
+		}
+		checkPlans(t, g, plans, 0, 7)
+
+		expected = expected[:min(10, len(expected))]
+		if len(plans) != len(expected) {
+			t.Fatalf("trial %v: expected %v plans; received %v", trial, len(expected), len(plans))
+		}
+
+		for idx, p := range plans {
+			if math.Abs(p.CostToGo-expected[idx]) > 1e-9 {
+				t.Errorf("trial %v: expected plan %v to cost %v; received %v", trial, idx, expected[idx], p.CostToGo)
+			}
+		}
+	}
+}
+
+/*
+TestYen_FindPlans3
+Description:
+
+	Tests that Yen's algorithm returns the plans found so far when it is cancelled.
+*/
+func TestYen_FindPlans3(t *testing.T) {
+	// Setup
+	g := createYenGraph()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Test
+	plans, err := kShortest.FindPlansWithContext(ctx, g, 0, 5, 3, planning.Budget{})
+	if _, ok := err.(gppErrors.SearchCancelled); !ok || len(plans) != 0 {
+		t.Errorf("expected a SearchCancelled error and no plans; received %v, %v", plans, err)
+	}
+
+	plans, err = kShortest.FindPlansWithContext(context.Background(), g, 0, 5, 3, planning.Budget{MaxCost: 5.5})
+	if _, ok := err.(gppErrors.SearchBudgetExceeded); !ok || len(plans) != 1 {
+		t.Errorf("expected a SearchBudgetExceeded error after one plan; received %v plans, %v", len(plans), err)
+	}
+}
@@ -0,0 +1,211 @@
+package lpaStar_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"github.com/GraphPathPlanning.go/planning/lpaStar"
+	"github.com/GraphPathPlanning.go/testing/fixtures"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+planner_test.go
+Description:
+
+	Tests the LPA* planner.
+*/
+
+/*
+TestPlanner_Plan1
+Description:
+
+	Tests that the first call to Plan finds an optimal plan.
+*/
+func TestPlanner_Plan1(t *testing.T) {
+	// Setup
+	n := 10
+	g := fixtures.LatticeGraph(n)
+	p, err := lpaStar.NewPlanner(g, 0, int64(n*n-1), fixtures.Euclidean)
+	if err != nil {
+		t.Fatalf("there was a problem creating the planner: %v", err)
+	}
+
+	// Test
+	plan, err := p.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	fixtures.CheckAgainstDjikstra(t, g, plan, 0, int64(n*n-1))
+}
+
+/*
+TestPlanner_UpdateEdge1
+Description:
+
+	Tests that the planner returns optimal plans while random edges
+	become cheaper, more expensive or blocked. The same changes are
+	applied to a reference graph, which is solved with Djikstra's algorithm.
+*/
+func TestPlanner_UpdateEdge1(t *testing.T) {
+	// Setup
+	n := 10
+	g := fixtures.LatticeGraph(n)
+	ref := fixtures.LatticeGraph(n)
+	start, goal := int64(n), int64(n*n-2)
+	rng := rand.New(rand.NewSource(13))
+	blocked := make(map[[2]int64]bool)
+
+	p, err := lpaStar.NewPlanner(g, start, goal, fixtures.Euclidean)
+	if err != nil {
+		t.Fatalf("there was a problem creating the planner: %v", err)
+	}
+
+	// Test
+	for query := 0; query < 20; query++ {
+		// Change a few edges (some of them on the current plan)
+		for change := 0; change < 5; change++ {
+			row, col := rng.Intn(n), rng.Intn(n-1)
+			from, to := int64(row*n+col), int64(row*n+col+1)
+			if rng.Intn(2) == 0 {
+				from, to = int64(col*n+row), int64((col+1)*n+row)
+			}
+			if blocked[[2]int64{from, to}] {
+				continue
+			}
+
+			// Keep the weights at least 1 so that the Euclidean heuristic stays admissible
+			newWeight := 1.0 + 2*rng.Float64()
+			if err := p.UpdateEdge(from, to, newWeight); err != nil {
+				t.Fatalf("there was a problem updating the edge: %v", err)
+			}
+			if err := ref.SetEdgeCost(from, to, position_graph.DistanceCost, newWeight); err != nil {
+				t.Fatalf("there was a problem updating the reference edge: %v", err)
+			}
+		}
+
+		plan, err := p.Plan(context.Background())
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+
+		fixtures.CheckAgainstDjikstra(t, ref, plan, start, goal)
+
+		// Block an edge on the plan
+		ids := plan.NodeIDs()
+		idx := rng.Intn(len(ids) - 1)
+		from, to := min(ids[idx], ids[idx+1]), max(ids[idx], ids[idx+1])
+		if err := p.UpdateEdge(to, from, math.Inf(1)); err != nil {
+			t.Fatalf("there was a problem blocking the edge: %v", err)
+		}
+		ref.RemoveEdge(from, to)
+		blocked[[2]int64{from, to}] = true
+
+		// Stop once the blocked edges cut the goal off from the start
+		plan, err = p.Plan(context.Background())
+		if _, refErr := djikstra.FindPlan(ref, start, goal); refErr != nil {
+			if !errors.As(err, &gppErrors.NoPathFound{}) {
+				t.Errorf("expected a NoPathFound error; received %v", err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+
+		fixtures.CheckAgainstDjikstra(t, ref, plan, start, goal)
+	}
+}
+
+/*
+TestPlanner_UpdateEdge2
+Description:
+
+	Tests that changing an edge that the search never reached does
+	not cause any expansions, and that the planner reports unknown edges.
+*/
+func TestPlanner_UpdateEdge2(t *testing.T) {
+	// Setup: a chain 0 - 1 - 2 with a far away node 3 attached to 2
+	g := position_graph.New()
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 0.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 50.0}))
+	g.AddEdgeBetween(n0, n1)
+	g.AddEdgeBetween(n1, n2)
+	g.AddEdgeBetween(n2, n3)
+
+	p, err := lpaStar.NewPlanner(g, n0.ID(), n1.ID(), fixtures.Euclidean)
+	if err != nil {
+		t.Fatalf("there was a problem creating the planner: %v", err)
+	}
+	if _, err := p.Plan(context.Background()); err != nil {
+		t.Fatalf("there was a problem finding the plan: %v", err)
+	}
+
+	// Test
+	if err := p.UpdateEdge(n2.ID(), n3.ID(), 7.0); err != nil {
+		t.Fatalf("there was a problem updating the edge: %v", err)
+	}
+
+	if w, ok := p.Weight(n3.ID(), n2.ID()); !ok || w != 7.0 {
+		t.Errorf("expected the updated weight to be 7 in both directions; received %v", w)
+	}
+
+	p.Budget = planning.Budget{MaxExpansions: 1}
+	if _, err := p.Plan(context.Background()); err != nil {
+		t.Errorf("expected the plan to be reused without expansions; received %v", err)
+	}
+
+	err = p.UpdateEdge(n0.ID(), n3.ID(), 1.0)
+	if err != (gppErrors.EdgeNotFound{From: n0.ID(), To: n3.ID()}) {
+		t.Errorf("expected an EdgeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestPlanner_UpdateEdge3
+Description:
+
+	Tests that on a directed graph, updates only apply in one direction,
+	and that the planner reports when the goal becomes unreachable.
+*/
+func TestPlanner_UpdateEdge3(t *testing.T) {
+	// Setup: a two-way connection between 0 and 1
+	g := position_graph.NewDirected()
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	g.AddEdgeBetween(n0, n1)
+	g.AddEdgeBetween(n1, n0)
+
+	p, err := lpaStar.NewPlanner(g, n0.ID(), n1.ID(), nil)
+	if err != nil {
+		t.Fatalf("there was a problem creating the planner: %v", err)
+	}
+
+	// Test
+	if err := p.UpdateEdge(n1.ID(), n0.ID(), math.Inf(1)); err != nil {
+		t.Fatalf("there was a problem updating the edge: %v", err)
+	}
+
+	plan, err := p.Plan(context.Background())
+	if err != nil || plan.CostToGo != 1.0 {
+		t.Errorf("expected the plan from 0 to 1 to be unaffected; received %v, %v", plan, err)
+	}
+
+	if err := p.UpdateEdge(n0.ID(), n1.ID(), math.Inf(1)); err != nil {
+		t.Fatalf("there was a problem updating the edge: %v", err)
+	}
+
+	_, err = p.Plan(context.Background())
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+}
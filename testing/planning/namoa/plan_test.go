@@ -0,0 +1,258 @@
+package namoa_test
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning/namoa"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+	"testing"
+)
+
+/*
+plan_test.go
+Description:
+
+	This file is meant to test all methods defined in the plan
+	file for NAMOA*.
+*/
+
+/*
+vectorGraph
+Description:
+
+	A small undirected graph whose edges carry cost vectors, for use in these tests.
+*/
+type vectorGraph struct {
+	*simple.UndirectedGraph
+	costs map[[2]int64][]float64
+}
+
+func newVectorGraph() *vectorGraph {
+	return &vectorGraph{
+		UndirectedGraph: simple.NewUndirectedGraph(),
+		costs:           make(map[[2]int64][]float64),
+	}
+}
+
+func (vg *vectorGraph) addEdge(from, to int64, costs ...float64) {
+	vg.SetEdge(simple.Edge{F: simple.Node(from), T: simple.Node(to)})
+	vg.costs[[2]int64{from, to}] = costs
+	vg.costs[[2]int64{to, from}] = costs
+}
+
+func (vg *vectorGraph) CostVector(xid, yid int64) ([]float64, bool) {
+	costs, ok := vg.costs[[2]int64{xid, yid}]
+	return costs, ok
+}
+
+/*
+CreateTestGraph_ForNAMOA1
+Description:
+
+	Creates a graph with four routes from node 0 to node 5:
+	- 0 -> 1 -> 5 costs (2, 10) (Pareto-optimal),
+	- 0 -> 2 -> 5 costs (10, 2) (Pareto-optimal),
+	- 0 -> 3 -> 5 costs (5, 5) (Pareto-optimal), and
+	- 0 -> 4 -> 5 costs (6, 11) (dominated by 0 -> 3 -> 5).
+*/
+func CreateTestGraph_ForNAMOA1() *vectorGraph {
+	// Constants
+	g := newVectorGraph()
+
+	// Algorithm
+	g.addEdge(0, 1, 1, 5)
+	g.addEdge(1, 5, 1, 5)
+	g.addEdge(0, 2, 5, 1)
+	g.addEdge(2, 5, 5, 1)
+	g.addEdge(0, 3, 2, 2)
+	g.addEdge(3, 5, 3, 3)
+	g.addEdge(0, 4, 3, 6)
+	g.addEdge(4, 5, 3, 5)
+	g.AddNode(simple.Node(6))
+
+	return g
+}
+
+/*
+TestPlan_FindParetoFront1
+Description:
+
+	Tests that FindParetoFront returns exactly the three
+	Pareto-optimal plans of the test graph.
+*/
+func TestPlan_FindParetoFront1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForNAMOA1()
+
+	// Algorithm
+	plans, err := namoa.FindParetoFront(g, 0, 5, nil)
+	if err != nil {
+		t.Fatalf("there was a problem finding the Pareto front: %v", err)
+	}
+
+	if len(plans) != 3 {
+		t.Fatalf("expected 3 plans in the Pareto front; received %v", len(plans))
+	}
+
+	expected := map[int64][]float64{
+		1: {2, 10},
+		2: {10, 2},
+		3: {5, 5},
+	}
+	for _, p := range plans {
+		if len(p.Sequence) != 3 {
+			t.Errorf("expected each plan to have 3 nodes; received %v", len(p.Sequence))
+			continue
+		}
+
+		via := p.Sequence[1].ID()
+		expectedCost, ok := expected[via]
+		if !ok {
+			t.Errorf("did not expect a plan through node %v", via)
+			continue
+		}
+
+		if !mat.Equal(mat.NewVecDense(2, expectedCost), mat.NewVecDense(2, p.CostToGo)) {
+			t.Errorf("expected plan through %v to cost %v; received %v", via, expectedCost, p.CostToGo)
+		}
+
+		if len(p.EdgeCosts) != 2 {
+			t.Errorf("expected 2 edge cost vectors; received %v", len(p.EdgeCosts))
+		}
+	}
+}
+
+/*
+TestPlan_FindParetoFront2
+Description:
+
+	Tests that a (vector) heuristic which is admissible does not
+	change the Pareto front.
+*/
+func TestPlan_FindParetoFront2(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForNAMOA1()
+	heuristic := func(pn *namoa.PlanningNode) []float64 {
+		if pn.NodeID() == 5 {
+			return []float64{0, 0}
+		}
+		return []float64{1, 1}
+	}
+
+	// Algorithm
+	plans, err := namoa.FindParetoFront(g, 0, 5, heuristic)
+	if err != nil {
+		t.Fatalf("there was a problem finding the Pareto front: %v", err)
+	}
+
+	if len(plans) != 3 {
+		t.Errorf("expected 3 plans in the Pareto front; received %v", len(plans))
+	}
+
+	for idx, p1 := range plans {
+		for jdx, p2 := range plans {
+			if idx != jdx && namoa.Dominates(p1.CostToGo, p2.CostToGo) {
+				t.Errorf("plan %v dominates plan %v", p1.CostToGo, p2.CostToGo)
+			}
+		}
+	}
+}
+
+/*
+TestPlan_FindParetoFront3
+Description:
+
+	Tests that FindParetoFront returns a NoPathFound error when
+	the end cannot be reached.
+*/
+func TestPlan_FindParetoFront3(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForNAMOA1()
+
+	// Algorithm
+	_, err := namoa.FindParetoFront(g, 0, 6, nil)
+	if err == nil {
+		t.Fatalf("no error was thrown, but one should have been!")
+	}
+
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+}
+
+/*
+TestDominance_Dominates1
+Description:
+
+	Tests the Dominates and WeaklyDominates functions.
+*/
+func TestDominance_Dominates1(t *testing.T) {
+	// Setup
+	a := []float64{1, 2}
+	b := []float64{1, 3}
+
+	// Test
+	if !namoa.Dominates(a, b) {
+		t.Errorf("expected %v to dominate %v", a, b)
+	}
+
+	if namoa.Dominates(a, a) {
+		t.Errorf("did not expect %v to dominate itself", a)
+	}
+
+	if !namoa.WeaklyDominates(a, a) {
+		t.Errorf("expected %v to weakly dominate itself", a)
+	}
+
+	if namoa.Dominates([]float64{0, 4}, b) {
+		t.Errorf("did not expect incomparable vectors to dominate each other")
+	}
+}
+
+var _ graph.Graph = &vectorGraph{}
+
+/*
+TestPlan_FindParetoFront4
+Description:
+
+	Tests that FindParetoFront works directly on a position graph whose
+	edges carry a second cost: the short route is risky and the long
+	route is safe, so both should be in the Pareto front.
+*/
+func TestPlan_FindParetoFront4(t *testing.T) {
+	// Setup
+	g := position_graph.New()
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 1.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 1.0}))
+	n4 := g.AddNodeAt(mat.NewVecDense(2, []float64{2.0, 1.0}))
+
+	g.AddEdgeWithCosts(n0, n3, map[string]float64{"risk": 10.0})
+	g.AddEdgeWithCosts(n0, n1, map[string]float64{"risk": 1.0})
+	g.AddEdgeWithCosts(n1, n4, map[string]float64{"risk": 1.0})
+	g.AddEdgeWithCosts(n4, n3, map[string]float64{"risk": 1.0})
+	g.AddEdgeWithCosts(n0, n2, map[string]float64{"risk": 20.0})
+	g.AddEdgeWithCosts(n2, n3, map[string]float64{"risk": 20.0})
+
+	// Algorithm
+	plans, err := namoa.FindParetoFront(g, n0.ID(), n3.ID(), nil)
+	if err != nil {
+		t.Fatalf("there was a problem finding the Pareto front: %v", err)
+	}
+
+	if len(plans) != 2 {
+		t.Errorf("expected 2 plans in the Pareto front; received %v", len(plans))
+	}
+
+	for _, p := range plans {
+		if len(p.CostToGo) != 2 {
+			t.Errorf("expected cost vectors with 2 components; received %v", p.CostToGo)
+		}
+		if p.Sequence[1].ID() == n2.ID() {
+			t.Errorf("the plan through node %v is dominated and should not be returned", n2.ID())
+		}
+	}
+}
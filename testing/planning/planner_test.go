@@ -0,0 +1,123 @@
+package planning_test
+
+import (
+	"context"
+	position_graph "github.com/GraphPathPlanning.go/graphs/position"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/aStar"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"testing"
+)
+
+/*
+planner_test.go
+Description:
+
+	Tests that the planners in this module can be used interchangeably
+	through the planning.Planner interface.
+*/
+
+/*
+CreateTestGraph_ForPlanner1
+Description:
+
+	Creates a small graph with a cheap, three-edge route and
+	an expensive, direct route between nodes 0 and 3.
+*/
+func CreateTestGraph_ForPlanner1() *position_graph.PositionGraph {
+	// Constants
+	g := position_graph.New()
+
+	// Algorithm
+	n0 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 0.0}))
+	n1 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.0, 1.0}))
+	n2 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 1.0}))
+	n3 := g.AddNodeAt(mat.NewVecDense(2, []float64{1.0, 0.0}))
+	n4 := g.AddNodeAt(mat.NewVecDense(2, []float64{0.5, -5.0}))
+
+	g.AddEdgeBetween(n0, n1)
+	g.AddEdgeBetween(n1, n2)
+	g.AddEdgeBetween(n2, n3)
+	g.AddEdgeBetween(n0, n4)
+	g.AddEdgeBetween(n4, n3)
+
+	return g
+}
+
+/*
+TestPlanner_Plan1
+Description:
+
+	Tests that every planner returns the same optimal plan on the same graph
+	and that the edge costs of each plan add up to its total cost.
+*/
+func TestPlanner_Plan1(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPlanner1()
+	planners := map[string]planning.Planner{
+		"aStar":    aStar.Planner{},
+		"djikstra": djikstra.Planner{},
+	}
+
+	// Test
+	for name, planner := range planners {
+		p, err := planner.Plan(context.Background(), g, 0, 3)
+		if err != nil {
+			t.Errorf("%v: there was a problem finding the plan: %v", name, err)
+			continue
+		}
+
+		expected := []int64{0, 1, 2, 3}
+		ids := p.NodeIDs()
+		if len(ids) != len(expected) {
+			t.Errorf("%v: expected plan %v; received %v", name, expected, ids)
+			continue
+		}
+		for idx := range expected {
+			if ids[idx] != expected[idx] {
+				t.Errorf("%v: expected plan %v; received %v", name, expected, ids)
+				break
+			}
+		}
+
+		if len(p.EdgeCosts) != len(p.Sequence)-1 {
+			t.Errorf(
+				"%v: expected %v edge costs; received %v",
+				name, len(p.Sequence)-1, len(p.EdgeCosts),
+			)
+		}
+
+		total := 0.0
+		for _, c := range p.EdgeCosts {
+			total += c
+		}
+		if math.Abs(total-p.CostToGo) > 1e-9 || math.Abs(p.CostToGo-3.0) > 1e-9 {
+			t.Errorf(
+				"%v: expected edge costs to sum to the total cost 3.0; received %v (total %v)",
+				name, total, p.CostToGo,
+			)
+		}
+	}
+}
+
+/*
+TestPlanner_Plan2
+Description:
+
+	Tests that the planners refuse to start if the context is already cancelled.
+*/
+func TestPlanner_Plan2(t *testing.T) {
+	// Setup
+	g := CreateTestGraph_ForPlanner1()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Test
+	for _, planner := range []planning.Planner{aStar.Planner{}, djikstra.Planner{}} {
+		if _, err := planner.Plan(ctx, g, 0, 3); err == nil {
+			t.Errorf("expected an error from a cancelled context; received nil")
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package rcsp_test
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning/rcsp"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+plan_test.go
+Description:
+
+	Tests the resource-constrained shortest path planner.
+*/
+
+/*
+vectorGraph
+Description:
+
+	A small undirected graph whose edges carry cost vectors, for use in these tests.
+*/
+type vectorGraph struct {
+	*simple.UndirectedGraph
+	costs map[[2]int64][]float64
+}
+
+func newVectorGraph() *vectorGraph {
+	return &vectorGraph{
+		UndirectedGraph: simple.NewUndirectedGraph(),
+		costs:           make(map[[2]int64][]float64),
+	}
+}
+
+func (vg *vectorGraph) addEdge(from, to int64, costs ...float64) {
+	vg.SetEdge(simple.Edge{F: simple.Node(from), T: simple.Node(to)})
+	vg.costs[[2]int64{from, to}] = costs
+	vg.costs[[2]int64{to, from}] = costs
+}
+
+func (vg *vectorGraph) CostVector(xid, yid int64) ([]float64, bool) {
+	costs, ok := vg.costs[[2]int64{xid, yid}]
+	return costs, ok
+}
+
+/*
+createDroneGraph
+Description:
+
+	Creates a graph with three routes from node 0 to node 4, whose edges
+	cost (flight time, battery use):
+	- 0 -> 1 -> 4 costs (2, 10) (fast, but uses the most battery),
+	- 0 -> 2 -> 4 costs (5, 6), and
+	- 0 -> 3 -> 4 costs (9, 3) (slow, but uses the least battery).
+	Node 5 is not connected to the others.
+*/
+func createDroneGraph() *vectorGraph {
+	g := newVectorGraph()
+	g.addEdge(0, 1, 1, 5)
+	g.addEdge(1, 4, 1, 5)
+	g.addEdge(0, 2, 2, 3)
+	g.addEdge(2, 4, 3, 3)
+	g.addEdge(0, 3, 4, 1)
+	g.addEdge(3, 4, 5, 2)
+	g.AddNode(simple.Node(5))
+
+	return g
+}
+
+/*
+TestPlan_FindPlan1
+Description:
+
+	Tests that the planner finds the fastest route within each battery limit.
+*/
+func TestPlan_FindPlan1(t *testing.T) {
+	// Setup
+	g := createDroneGraph()
+	testCases := []struct {
+		battery      float64
+		expectedTime float64
+		expectedVia  int64
+	}{
+		{math.Inf(1), 2, 1},
+		{10, 2, 1},
+		{9.5, 5, 2},
+		{6, 5, 2},
+		{5, 9, 3},
+		{3, 9, 3},
+	}
+
+	// Test
+	for _, tc := range testCases {
+		p, err := rcsp.FindPlan(g, 0, 4, rcsp.Constraints{Objective: 0, Limits: []float64{math.Inf(1), tc.battery}}, nil)
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan with a battery of %v: %v", tc.battery, err)
+		}
+
+		if p.CostToGo[0] != tc.expectedTime || p.Sequence[1].ID() != tc.expectedVia {
+			t.Errorf(
+				"expected the plan via node %v to take %v with a battery of %v; received via node %v, %v",
+				tc.expectedVia, tc.expectedTime, tc.battery, p.Sequence[1].ID(), p.CostToGo,
+			)
+		}
+
+		if len(p.EdgeCosts) != 2 || p.EdgeCosts[0][1]+p.EdgeCosts[1][1] != p.CostToGo[1] {
+			t.Errorf("expected the edge costs of the plan to add up to %v; received %v", p.CostToGo, p.EdgeCosts)
+		}
+	}
+}
+
+/*
+TestPlan_FindPlan2
+Description:
+
+	Tests that the planner tells infeasible constraints apart from missing paths.
+*/
+func TestPlan_FindPlan2(t *testing.T) {
+	// Setup
+	g := createDroneGraph()
+	constraints := rcsp.Constraints{Objective: 0, Limits: []float64{math.Inf(1), 2.5}}
+
+	// Test
+	_, err := rcsp.FindPlan(g, 0, 4, constraints, nil)
+	if _, ok := err.(gppErrors.InfeasibleConstraints); !ok {
+		t.Errorf("expected an InfeasibleConstraints error; received %v", err)
+	}
+
+	_, err = rcsp.FindPlan(g, 0, 5, constraints, nil)
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+
+	_, err = rcsp.FindPlan(g, 6, 4, constraints, nil)
+	if err != (gppErrors.StartNodeNotFound{ID: 6}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan3
+Description:
+
+	Tests that the planner finds the same objective cost as a brute-force
+	search over every loopless path of random graphs with two resources.
+*/
+func TestPlan_FindPlan3(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(20))
+
+	for trial := 0; trial < 200; trial++ {
+		g := newVectorGraph()
+		for from := int64(0); from < 9; from++ {
+			for to := from + 1; to < 9; to++ {
+				if rng.Float64() < 0.4 {
+					g.addEdge(from, to, float64(1+rng.Intn(9)), float64(rng.Intn(9)), float64(rng.Intn(9)))
+				}
+			}
+		}
+		if g.Node(0) == nil || g.Node(8) == nil {
+			continue
+		}
+
+		limits := []float64{math.Inf(1), float64(5 + rng.Intn(15)), float64(5 + rng.Intn(15))}
+
+		// Find the best feasible path by brute force
+		best, anyPath := math.Inf(1), false
+		visited := map[int64]bool{0: true}
+		var visit func(id int64, costs [3]float64)
+		visit = func(id int64, costs [3]float64) {
+			if id == 8 {
+				anyPath = true
+				if costs[1] <= limits[1] && costs[2] <= limits[2] {
+					best = math.Min(best, costs[0])
+				}
+				return
+			}
+
+			neighbors := g.From(id)
+			for neighbors.Next() {
+				next := neighbors.Node().ID()
+				if visited[next] {
+					continue
+				}
+
+				edgeCosts, _ := g.CostVector(id, next)
+				visited[next] = true
+				visit(next, [3]float64{costs[0] + edgeCosts[0], costs[1] + edgeCosts[1], costs[2] + edgeCosts[2]})
+				visited[next] = false
+			}
+		}
+		visit(0, [3]float64{})
+
+		// Test
+		p, err := rcsp.FindPlan(g, 0, 8, rcsp.Constraints{Objective: 0, Limits: limits}, nil)
+		switch {
+		case !anyPath:
+			if _, ok := err.(gppErrors.NoPathFound); !ok {
+				t.Errorf("trial %v: expected a NoPathFound error; received %v", trial, err)
+			}
+		case math.IsInf(best, 1):
+			if _, ok := err.(gppErrors.InfeasibleConstraints); !ok {
+				t.Errorf("trial %v: expected an InfeasibleConstraints error; received %v", trial, err)
+			}
+		case err != nil:
+			t.Errorf("trial %v: there was a problem finding the plan: %v", trial, err)
+		case p.CostToGo[0] != best || p.CostToGo[1] > limits[1] || p.CostToGo[2] > limits[2]:
+			t.Errorf("trial %v: expected a plan of cost %v within %v; received %v", trial, best, limits, p.CostToGo)
+		}
+	}
+}
+
+/*
+TestPlan_FindPlan4
+Description:
+
+	Tests that the planner rejects an objective that is not a cost
+	component of the graph and negative or NaN limits.
+*/
+func TestPlan_FindPlan4(t *testing.T) {
+	// Setup
+	g := createDroneGraph()
+
+	// Test
+	for _, constraints := range []rcsp.Constraints{
+		{Objective: -1},
+		{Objective: 2},
+		{Objective: 0, Limits: []float64{math.Inf(1), -1.0}},
+		{Objective: 0, Limits: []float64{math.NaN()}},
+	} {
+		_, err := rcsp.FindPlan(g, 0, 4, constraints, nil)
+		if _, ok := err.(gppErrors.InvalidOption); !ok {
+			t.Errorf("expected an InvalidOption error for the constraints %v; received %v", constraints, err)
+		}
+	}
+}
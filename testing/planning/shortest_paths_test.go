@@ -0,0 +1,91 @@
+package planning_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	"github.com/GraphPathPlanning.go/planning"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"testing"
+)
+
+/*
+shortest_paths_test.go
+Description:
+
+	Tests Djikstra's algorithm over a whole graph.
+*/
+
+/*
+TestShortestPaths1
+Description:
+
+	Tests the distances and parents from one and from two sources on a
+	small directed graph, and the distances to a node on its reverse.
+*/
+func TestShortestPaths1(t *testing.T) {
+	// Setup: 0 -> 1 -> 2 -> 3 with a more expensive shortcut 0 -> 2, and 4 -> 3
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []struct {
+		from, to int64
+		w        float64
+	}{{0, 1, 1.0}, {1, 2, 1.0}, {0, 2, 3.0}, {2, 3, 2.0}, {4, 3, 1.0}} {
+		g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(e.from), simple.Node(e.to), e.w))
+	}
+
+	// Test
+	tree, err := planning.ShortestPaths(context.Background(), g, 0)
+	if err != nil {
+		t.Fatalf("there was a problem finding the shortest paths: %v", err)
+	}
+	expected := map[int64]float64{0: 0.0, 1: 1.0, 2: 2.0, 3: 4.0}
+	for id, d := range expected {
+		if tree.Distances[id] != d {
+			t.Errorf("expected node %v at distance %v; received %v", id, d, tree.Distances[id])
+		}
+	}
+	if _, ok := tree.Distances[4]; ok || len(tree.Distances) != len(expected) {
+		t.Errorf("expected only nodes 0 to 3 to be reached; received %v", tree.Distances)
+	}
+	if tree.Parents[2] != 1 || tree.Parents[3] != 2 {
+		t.Errorf("expected the parents 1 of node 2 and 2 of node 3; received %v", tree.Parents)
+	}
+	if _, ok := tree.Parents[0]; ok {
+		t.Errorf("did not expect the source to have a parent")
+	}
+
+	tree, _ = planning.ShortestPaths(context.Background(), g, 0, 4)
+	if tree.Distances[3] != 1.0 || tree.Parents[3] != 4 {
+		t.Errorf("expected node 3 to be reached from node 4 at distance 1; received %v", tree.Distances[3])
+	}
+
+	tree, _ = planning.ShortestPaths(context.Background(), planning.Reverse(g), 3)
+	if tree.Distances[0] != 4.0 || tree.Distances[4] != 1.0 {
+		t.Errorf("expected the distances 4 from node 0 and 1 from node 4 to node 3; received %v", tree.Distances)
+	}
+}
+
+/*
+TestShortestPaths2
+Description:
+
+	Tests that ShortestPaths rejects negative weights and stops
+	when its context is done.
+*/
+func TestShortestPaths2(t *testing.T) {
+	// Setup
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(0), simple.Node(1), -1.0))
+
+	// Test
+	if _, err := planning.ShortestPaths(context.Background(), g, 0); !errors.As(err, &gppErrors.UnsupportedGraph{}) {
+		t.Errorf("expected an UnsupportedGraph error; received %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := planning.ShortestPaths(ctx, g, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled search; received %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package thetaStar_test
+
+import (
+	"context"
+	"github.com/GraphPathPlanning.go/gppErrors"
+	grid_graph "github.com/GraphPathPlanning.go/graphs/grid"
+	"github.com/GraphPathPlanning.go/planning"
+	"github.com/GraphPathPlanning.go/planning/djikstra"
+	"github.com/GraphPathPlanning.go/planning/thetaStar"
+	"github.com/GraphPathPlanning.go/testing/fixtures"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+/*
+plan_test.go
+Description:
+
+	Tests the Theta* and Lazy Theta* planners.
+*/
+
+/*
+euclideanHeuristic
+Description:
+
+	Returns a heuristic that measures the distance between
+	the centers of two cells of the grid g.
+*/
+func euclideanHeuristic(g *grid_graph.Grid) thetaStar.Heuristic {
+	return func(from, to graph.Node) float64 {
+		var diff mat.VecDense
+		diff.SubVec(g.Position(g.Cell(to.ID())...), g.Position(g.Cell(from.ID())...))
+		return mat.Norm(&diff, 2)
+	}
+}
+
+/*
+TestPlan_FindPlan1
+Description:
+
+	Tests that Theta* and Lazy Theta* plan a single straight segment across an empty grid,
+	and that a wall makes them plan around it.
+*/
+func TestPlan_FindPlan1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(10, 10)
+	start, end := g.ID(0, 0), g.ID(9, 4)
+
+	for _, findPlan := range []func(graph.Weighted, int64, int64, thetaStar.Heuristic, thetaStar.LineOfSight) (*planning.Plan, error){
+		thetaStar.FindPlan,
+		thetaStar.FindPlanLazy,
+	} {
+		// Test: an empty grid
+		p, err := findPlan(g, start, end, euclideanHeuristic(g), g.LineOfSight)
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+
+		if len(p.Sequence) != 2 || math.Abs(p.CostToGo-math.Sqrt(97.0)) > 1e-9 {
+			t.Errorf("expected a straight plan of cost %v; received %v with cost %v", math.Sqrt(97.0), p.NodeIDs(), p.CostToGo)
+		}
+	}
+
+	// Test: a wall from {5, 0} to {5, 8}
+	for y := 0; y < 9; y++ {
+		g.Block(5, y)
+	}
+
+	for _, findPlan := range []func(graph.Weighted, int64, int64, thetaStar.Heuristic, thetaStar.LineOfSight) (*planning.Plan, error){
+		thetaStar.FindPlan,
+		thetaStar.FindPlanLazy,
+	} {
+		p, err := findPlan(g, start, end, euclideanHeuristic(g), g.LineOfSight)
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+		fixtures.CheckPlan(t, p, start, end, g.LineOfSight)
+
+		dp, _ := djikstra.FindPlan(g, start, end)
+		if p.CostToGo >= dp.CostToGo {
+			t.Errorf("expected an any-angle plan cheaper than %v; received %v", dp.CostToGo, p.CostToGo)
+		}
+	}
+}
+
+/*
+TestPlan_FindPlan2
+Description:
+
+	Tests that Theta* and Lazy Theta* find valid plans that are never more expensive
+	than the shortest plan along the edges, on random grids with and without costs.
+*/
+func TestPlan_FindPlan2(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(17))
+	policies := []grid_graph.DiagonalPolicy{
+		grid_graph.NoCornerCutting,
+		grid_graph.AllowCornerCutting,
+		grid_graph.AllowSqueezing,
+	}
+
+	// Test
+	for trial := 0; trial < 300; trial++ {
+		g := fixtures.RandomGrid(rng, 25, 25, 0.3, trial%2 == 1)
+		g.Diagonal = policies[trial%len(policies)]
+		start := g.ID(rng.Intn(25), rng.Intn(25))
+		end := g.ID(rng.Intn(25), rng.Intn(25))
+		g.Unblock(g.Cell(start)...)
+		g.Unblock(g.Cell(end)...)
+
+		dp, dErr := djikstra.FindPlan(g, start, end)
+
+		p, err := thetaStar.FindPlan(g, start, end, euclideanHeuristic(g), g.LineOfSight)
+		lp, lErr := thetaStar.FindPlanLazy(g, start, end, euclideanHeuristic(g), g.LineOfSight)
+
+		if dErr != nil {
+			if _, ok := err.(gppErrors.NoPathFound); !ok {
+				t.Errorf("trial %v: expected a NoPathFound error from Theta*; received %v", trial, err)
+			}
+			if _, ok := lErr.(gppErrors.NoPathFound); !ok {
+				t.Errorf("trial %v: expected a NoPathFound error from Lazy Theta*; received %v", trial, lErr)
+			}
+			continue
+		}
+
+		if err != nil || lErr != nil {
+			t.Fatalf("trial %v: there was a problem finding the plans: %v, %v", trial, err, lErr)
+		}
+
+		fixtures.CheckPlan(t, p, start, end, g.LineOfSight)
+		fixtures.CheckPlan(t, lp, start, end, g.LineOfSight)
+
+		if p.CostToGo > dp.CostToGo+1e-9 || lp.CostToGo > dp.CostToGo+1e-9 {
+			t.Errorf(
+				"trial %v: expected plans no more expensive than %v; received %v (Theta*) and %v (Lazy Theta*)",
+				trial, dp.CostToGo, p.CostToGo, lp.CostToGo,
+			)
+		}
+	}
+}
+
+/*
+TestPlan_FindPlan3
+Description:
+
+	Tests that Theta* reports missing endpoints and stops when its budget runs out.
+*/
+func TestPlan_FindPlan3(t *testing.T) {
+	// Setup
+	g := grid_graph.New(50, 50)
+	g.Block(3, 3)
+
+	// Test
+	_, err := thetaStar.FindPlan(g, g.ID(3, 3), g.ID(4, 4), nil, g.LineOfSight)
+	if err != (gppErrors.StartNodeNotFound{ID: g.ID(3, 3)}) {
+		t.Errorf("expected a StartNodeNotFound error; received %v", err)
+	}
+
+	_, err = thetaStar.FindPlanLazyWithContext(
+		context.Background(), g, g.ID(0, 0), g.ID(49, 49), nil, g.LineOfSight,
+		planning.Budget{MaxExpansions: 10},
+	)
+	if _, ok := err.(gppErrors.SearchBudgetExceeded); !ok {
+		t.Errorf("expected a SearchBudgetExceeded error; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan4
+Description:
+
+	Tests that Theta* and Lazy Theta* behave like Djikstra's
+	algorithm when no LineOfSight is given.
+*/
+func TestPlan_FindPlan4(t *testing.T) {
+	// Setup
+	g := grid_graph.New(10, 10)
+	start, end := g.ID(0, 0), g.ID(9, 4)
+	dp, _ := djikstra.FindPlan(g, start, end)
+
+	// Test
+	for _, findPlan := range []func(graph.Weighted, int64, int64, thetaStar.Heuristic, thetaStar.LineOfSight) (*planning.Plan, error){
+		thetaStar.FindPlan,
+		thetaStar.FindPlanLazy,
+	} {
+		p, err := findPlan(g, start, end, euclideanHeuristic(g), nil)
+		if err != nil || math.Abs(p.CostToGo-dp.CostToGo) > 1e-9 {
+			t.Errorf("expected a plan of cost %v; received %v, %v", dp.CostToGo, p, err)
+		}
+	}
+}
+
+/*
+TestPlanner_Plan1
+Description:
+
+	Tests that the Planner behaves like Djikstra's algorithm without a LineOfSight,
+	and takes shortcuts with one.
+*/
+func TestPlanner_Plan1(t *testing.T) {
+	// Setup
+	g := grid_graph.New(10, 10)
+	start, end := g.ID(0, 0), g.ID(9, 4)
+	dp, _ := djikstra.FindPlan(g, start, end)
+
+	// Test
+	p, err := thetaStar.Planner{}.Plan(context.Background(), g, start, end)
+	if err != nil || math.Abs(p.CostToGo-dp.CostToGo) > 1e-9 {
+		t.Errorf("expected a plan of cost %v; received %v, %v", dp.CostToGo, p, err)
+	}
+
+	planner := thetaStar.Planner{Heuristic: euclideanHeuristic(g), LineOfSight: g.LineOfSight, Lazy: true}
+	p, err = planner.Plan(context.Background(), g, start, end)
+	if err != nil || len(p.Sequence) != 2 {
+		t.Errorf("expected a straight plan; received %v, %v", p, err)
+	}
+}
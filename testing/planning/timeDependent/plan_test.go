@@ -0,0 +1,207 @@
+package timeDependent_test
+
+import (
+	"github.com/GraphPathPlanning.go/gppErrors"
+	time_dependent_graph "github.com/GraphPathPlanning.go/graphs/timeDependent"
+	"github.com/GraphPathPlanning.go/planning/timeDependent"
+	"gonum.org/v1/gonum/graph/simple"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+/*
+plan_test.go
+Description:
+
+	Tests the time-dependent planner.
+*/
+
+/*
+createCommuteGraph
+Description:
+
+	Creates a graph with two routes from node 0 to node 3:
+	- the highway 0 -> 1 -> 3, which takes 2 + 2 outside of rush hour, but
+	  whose first edge takes up to 8 when entered between 8 and 15, and
+	- the side road 0 -> 2 -> 3, which always takes 3 + 3.
+*/
+func createCommuteGraph(t *testing.T) *time_dependent_graph.Graph {
+	t.Helper()
+
+	wg := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []struct {
+		from, to int64
+		weight   float64
+	}{
+		{0, 1, 2}, {1, 3, 2}, {0, 2, 3}, {2, 3, 3},
+	} {
+		wg.SetWeightedEdge(wg.NewWeightedEdge(simple.Node(e.from), simple.Node(e.to), e.weight))
+	}
+	wg.AddNode(simple.Node(4))
+
+	g := time_dependent_graph.New(wg)
+	rushHour, err := time_dependent_graph.NewProfile(
+		time_dependent_graph.Breakpoint{Time: 8.0, TravelTime: 2.0},
+		time_dependent_graph.Breakpoint{Time: 9.0, TravelTime: 8.0},
+		time_dependent_graph.Breakpoint{Time: 15.0, TravelTime: 2.0},
+	)
+	if err != nil {
+		t.Fatalf("there was a problem creating the profile: %v", err)
+	}
+
+	if err := g.SetProfile(0, 1, rushHour); err != nil {
+		t.Fatalf("there was a problem setting the profile: %v", err)
+	}
+
+	return g
+}
+
+/*
+TestPlan_FindPlan1
+Description:
+
+	Tests that the planner takes the highway outside of rush hour and
+	the side road during rush hour, and reports the arrival times.
+*/
+func TestPlan_FindPlan1(t *testing.T) {
+	// Setup
+	g := createCommuteGraph(t)
+	testCases := []struct {
+		departure        float64
+		expectedIDs      []int64
+		expectedArrivals []float64
+	}{
+		{6.0, []int64{0, 1, 3}, []float64{6.0, 8.0, 10.0}},
+		{8.5, []int64{0, 2, 3}, []float64{8.5, 11.5, 14.5}},
+		{16.0, []int64{0, 1, 3}, []float64{16.0, 18.0, 20.0}},
+	}
+
+	// Test
+	for _, tc := range testCases {
+		p, err := timeDependent.FindPlan(g, 0, 3, tc.departure, nil)
+		if err != nil {
+			t.Fatalf("there was a problem finding the plan: %v", err)
+		}
+
+		if !slices.Equal(p.NodeIDs(), tc.expectedIDs) || !slices.Equal(p.Arrivals, tc.expectedArrivals) {
+			t.Errorf(
+				"expected plan %v arriving at %v when leaving at %v; received %v arriving at %v",
+				tc.expectedIDs, tc.expectedArrivals, tc.departure, p.NodeIDs(), p.Arrivals,
+			)
+		}
+
+		last := len(p.Arrivals) - 1
+		if p.CostToGo != p.Arrivals[last]-tc.departure || p.EdgeCosts[0] != p.Arrivals[1]-p.Arrivals[0] {
+			t.Errorf("expected the costs of the plan to match its arrival times; received %v", p.EdgeCosts)
+		}
+	}
+
+	_, err := timeDependent.FindPlan(g, 0, 4, 0.0, nil)
+	if _, ok := err.(gppErrors.NoPathFound); !ok {
+		t.Errorf("expected a NoPathFound error; received %v", err)
+	}
+}
+
+/*
+TestPlan_FindPlan2
+Description:
+
+	Tests that the planner (with and without a heuristic) arrives as early
+	as the best loopless path found by brute force on random graphs with
+	random FIFO profiles.
+*/
+func TestPlan_FindPlan2(t *testing.T) {
+	// Setup
+	rng := rand.New(rand.NewSource(21))
+
+	for trial := 0; trial < 100; trial++ {
+		wg := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for from := int64(0); from < 8; from++ {
+			for to := int64(0); to < 8; to++ {
+				if from != to && rng.Float64() < 0.3 {
+					wg.SetWeightedEdge(wg.NewWeightedEdge(simple.Node(from), simple.Node(to), 1.0))
+				}
+			}
+		}
+		if wg.Node(0) == nil || wg.Node(7) == nil {
+			continue
+		}
+
+		// Give every edge a random profile whose travel time falls no faster than time passes
+		g := time_dependent_graph.New(wg)
+		edges := wg.Edges()
+		for edges.Next() {
+			e := edges.Edge()
+			breakpoints := []time_dependent_graph.Breakpoint{{Time: 0.0, TravelTime: 1.0 + 5.0*rng.Float64()}}
+			for idx := 1; idx < 5; idx++ {
+				previous := breakpoints[idx-1]
+				breakpoints = append(breakpoints, time_dependent_graph.Breakpoint{
+					Time:       float64(idx) * 3.0,
+					TravelTime: max(0.5, previous.TravelTime+3.0*(2.0*rng.Float64()-1.0)),
+				})
+			}
+
+			profile, err := time_dependent_graph.NewProfile(breakpoints...)
+			if err != nil {
+				t.Fatalf("there was a problem creating the profile: %v", err)
+			}
+			_ = g.SetProfile(e.From().ID(), e.To().ID(), profile)
+		}
+
+		// Lower bound on the remaining travel time: at least one more edge, which takes at least 0.5
+		heuristic := func(pn *timeDependent.PlanningNode) float64 {
+			if pn.NodeID() == 7 {
+				return 0.0
+			}
+			return 0.5
+		}
+
+		departure := 12.0 * rng.Float64()
+
+		// Find the earliest arrival by brute force
+		best := math.Inf(1)
+		visited := map[int64]bool{0: true}
+		var visit func(id int64, arrival float64)
+		visit = func(id int64, arrival float64) {
+			if id == 7 {
+				best = min(best, arrival)
+				return
+			}
+
+			neighbors := g.From(id)
+			for neighbors.Next() {
+				next := neighbors.Node().ID()
+				if visited[next] {
+					continue
+				}
+
+				travelTime, _ := g.TravelTime(id, next, arrival)
+				visited[next] = true
+				visit(next, arrival+travelTime)
+				visited[next] = false
+			}
+		}
+		visit(0, departure)
+
+		// Test
+		for _, h := range []func(*timeDependent.PlanningNode) float64{nil, heuristic} {
+			p, err := timeDependent.FindPlan(g, 0, 7, departure, h)
+			if math.IsInf(best, 1) {
+				if _, ok := err.(gppErrors.NoPathFound); !ok {
+					t.Errorf("trial %v: expected a NoPathFound error; received %v", trial, err)
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("trial %v: there was a problem finding the plan: %v", trial, err)
+			}
+
+			if arrival := p.Arrivals[len(p.Arrivals)-1]; math.Abs(arrival-best) > 1e-9 {
+				t.Errorf("trial %v: expected to arrive at %v; received %v", trial, best, arrival)
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package planningHeap_test
+
+import (
+	"github.com/GraphPathPlanning.go/planningHeap"
+	"testing"
+)
+
+/*
+indexed_planning_heap_test.go
+Description:
+
+	Tests the IndexedPlanningHeap object.
+*/
+
+/*
+testNode
+Description:
+
+	A minimal IndexedPlanningNode used in these tests.
+*/
+type testNode struct {
+	id   int64
+	cost float64
+}
+
+func (tn *testNode) Cost() float64 { return tn.cost }
+func (tn *testNode) NodeID() int64 { return tn.id }
+
+/*
+TestIndexedPlanningHeap_PopMin1
+Description:
+
+	Tests that PopMin returns nodes in increasing order of cost.
+*/
+func TestIndexedPlanningHeap_PopMin1(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	costs := []float64{5.0, 1.0, 4.0, 2.0, 3.0}
+	for idx, cost := range costs {
+		iph.Push(&testNode{id: int64(idx), cost: cost})
+	}
+
+	// Test
+	expected := []int64{1, 3, 4, 2, 0}
+	for _, expectedID := range expected {
+		pn := iph.PopMin()
+		if pn.NodeID() != expectedID {
+			t.Errorf("expected node %v to be popped; received %v", expectedID, pn.NodeID())
+		}
+	}
+
+	if iph.Len() != 0 {
+		t.Errorf("expected heap to be empty; it has %v elements", iph.Len())
+	}
+
+	if iph.PopMin() != nil {
+		t.Errorf("expected PopMin on an empty heap to return nil")
+	}
+}
+
+/*
+TestIndexedPlanningHeap_Push1
+Description:
+
+	Tests that pushing a second planning node for the same graph node
+	replaces the first one instead of adding a duplicate.
+*/
+func TestIndexedPlanningHeap_Push1(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	iph.Push(&testNode{id: 7, cost: 3.0})
+	iph.Push(&testNode{id: 7, cost: 1.0})
+
+	// Test
+	if iph.Len() != 1 {
+		t.Errorf("expected 1 element in the heap; received %v", iph.Len())
+	}
+
+	if iph.Min().Cost() != 1.0 {
+		t.Errorf("expected the remaining entry to have cost 1.0; received %v", iph.Min().Cost())
+	}
+}
+
+/*
+TestIndexedPlanningHeap_Contains1
+Description:
+
+	Tests that Contains tracks nodes as they are pushed and popped.
+*/
+func TestIndexedPlanningHeap_Contains1(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	iph.Push(&testNode{id: 1, cost: 1.0})
+	iph.Push(&testNode{id: 2, cost: 2.0})
+
+	// Test
+	if !iph.Contains(1) || !iph.Contains(2) {
+		t.Errorf("expected heap to contain nodes 1 and 2")
+	}
+
+	iph.PopMin()
+	if iph.Contains(1) {
+		t.Errorf("expected node 1 to be gone after PopMin")
+	}
+
+	if !iph.Contains(2) {
+		t.Errorf("expected node 2 to still be in the heap")
+	}
+}
+
+/*
+TestIndexedPlanningHeap_DecreaseKey1
+Description:
+
+	Tests that DecreaseKey moves a node to the front of the heap
+	when its cost drops, and ignores attempts to increase the cost.
+*/
+func TestIndexedPlanningHeap_DecreaseKey1(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	iph.Push(&testNode{id: 1, cost: 1.0})
+	iph.Push(&testNode{id: 2, cost: 2.0})
+	iph.Push(&testNode{id: 3, cost: 3.0})
+
+	// Test
+	if !iph.DecreaseKey(&testNode{id: 3, cost: 0.5}) {
+		t.Errorf("expected DecreaseKey to lower the cost of node 3")
+	}
+
+	if iph.Min().NodeID() != 3 {
+		t.Errorf("expected node 3 to be the minimum; received %v", iph.Min().NodeID())
+	}
+
+	if iph.DecreaseKey(&testNode{id: 1, cost: 10.0}) {
+		t.Errorf("expected DecreaseKey to ignore a higher cost")
+	}
+
+	if iph.DecreaseKey(&testNode{id: 4, cost: 0.0}) {
+		t.Errorf("expected DecreaseKey to ignore a node that is not in the heap")
+	}
+
+	if iph.Len() != 3 {
+		t.Errorf("expected 3 elements in the heap; received %v", iph.Len())
+	}
+}
+
+/*
+TestIndexedPlanningHeap_Update1
+Description:
+
+	Tests that Update can increase the cost of an existing node
+	and that it pushes nodes that are not yet in the heap.
+*/
+func TestIndexedPlanningHeap_Update1(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	iph.Push(&testNode{id: 1, cost: 1.0})
+	iph.Push(&testNode{id: 2, cost: 2.0})
+
+	// Test
+	iph.Update(&testNode{id: 1, cost: 5.0})
+	iph.Update(&testNode{id: 3, cost: 3.0})
+
+	expected := []int64{2, 3, 1}
+	for _, expectedID := range expected {
+		pn := iph.PopMin()
+		if pn.NodeID() != expectedID {
+			t.Errorf("expected node %v to be popped; received %v", expectedID, pn.NodeID())
+		}
+	}
+}
+
+/*
+tieBreakingNode
+Description:
+
+	A minimal IndexedPlanningNode with a TieBreakCost used in these tests.
+*/
+type tieBreakingNode struct {
+	testNode
+	tieBreak float64
+}
+
+func (tbn *tieBreakingNode) TieBreakCost() float64 { return tbn.tieBreak }
+
+/*
+TestIndexedPlanningHeap_PopMin2
+Description:
+
+	Tests that nodes with equal costs are ordered by their TieBreakCost.
+*/
+func TestIndexedPlanningHeap_PopMin2(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	iph.Push(&tieBreakingNode{testNode{id: 1, cost: 1.0}, 3.0})
+	iph.Push(&tieBreakingNode{testNode{id: 2, cost: 1.0}, 1.0})
+	iph.Push(&tieBreakingNode{testNode{id: 3, cost: 0.5}, 9.0})
+	iph.Push(&tieBreakingNode{testNode{id: 4, cost: 1.0}, 2.0})
+
+	// Test
+	expected := []int64{3, 2, 4, 1}
+	for _, expectedID := range expected {
+		pn := iph.PopMin()
+		if pn.NodeID() != expectedID {
+			t.Errorf("expected node %v to be popped; received %v", expectedID, pn.NodeID())
+		}
+	}
+}
+
+/*
+TestIndexedPlanningHeap_Remove1
+Description:
+
+	Tests that Remove takes a node out of the middle of the heap.
+*/
+func TestIndexedPlanningHeap_Remove1(t *testing.T) {
+	// Setup
+	iph := planningHeap.NewIndexedPlanningHeap()
+	for idx := int64(0); idx < 5; idx++ {
+		iph.Push(&testNode{id: idx, cost: float64(idx)})
+	}
+
+	// Test
+	if removed := iph.Remove(2); removed == nil || removed.NodeID() != 2 {
+		t.Errorf("expected Remove to return node 2; received %v", removed)
+	}
+
+	if iph.Contains(2) || iph.Len() != 4 {
+		t.Errorf("expected node 2 to be gone from the heap")
+	}
+
+	if iph.Remove(2) != nil {
+		t.Errorf("expected removing a missing node to return nil")
+	}
+
+	expected := []int64{0, 1, 3, 4}
+	for _, expectedID := range expected {
+		pn := iph.PopMin()
+		if pn.NodeID() != expectedID {
+			t.Errorf("expected node %v to be popped; received %v", expectedID, pn.NodeID())
+		}
+	}
+}